@@ -1,7 +1,10 @@
 package accessor
 
 import (
+	"fmt"
 	"reflect"
+
+	"gopkg.in/yaml.v2"
 )
 
 // Accessor provides getter/setter to the object.
@@ -14,6 +17,10 @@ type Accessor interface {
 	// NoSuchPathError is returned when the path is invalid.
 	Set(path Path, value interface{}) error
 
+	// Delete removes a object at specific path.
+	// NoSuchPathError is returned when no object was found in the path.
+	Delete(path Path) error
+
 	// Unwrap unwraps the object and returns actual value.
 	Unwrap() interface{}
 
@@ -21,40 +28,136 @@ type Accessor interface {
 	Foreach(f func(path Path, value interface{}) error) error
 }
 
+// Option is an option for NewAccessor.
+type Option func(*options)
+
+type options struct {
+	stringifyKeys bool
+	preserveTypes bool
+	lazy          bool
+	preserveOrder bool
+}
+
+// StringifyKeys makes NewAccessor accept a map whose keys are scalar values
+// such as int, bool and float64 by converting the keys into strings.
+// A map whose key type is not string becomes a KeyedMapAccessor even if all keys are strings,
+// which restores the original keys on Unwrap.
+func StringifyKeys() Option {
+	return func(o *options) {
+		o.stringifyKeys = true
+	}
+}
+
+// PreserveTypes makes NewAccessor record the type of maps and slices
+// such as map[string]string or []int, which is otherwise lost on Unwrap.
+// Such a map or slice becomes a TypedAccessor, which rebuilds the value of the type on Unwrap.
+func PreserveTypes() Option {
+	return func(o *options) {
+		o.preserveTypes = true
+	}
+}
+
+// Lazy makes NewAccessor convert the maps and slices in the object
+// only when they are accessed, which is efficient for a large object
+// where only a few values are used.
+// The children are wrapped by LazyAccessor, and errors such as InvalidKeyError
+// are returned when the child is accessed instead of by NewAccessor.
+// The children can be read concurrently like the other Accessors.
+func Lazy() Option {
+	return func(o *options) {
+		o.lazy = true
+	}
+}
+
 // NewAccessor creates a new Accessor from a object.
 // The object is a map[string]interface{} or []interface{}.
-func NewAccessor(acc interface{}) (Accessor, error) {
+// A struct or a pointer to a struct becomes a StructAccessor,
+// and yaml.MapSlice becomes an OrderedMapAccessor.
+func NewAccessor(acc interface{}, opts ...Option) (Accessor, error) {
+	return newAccessor(acc, newOptions(opts))
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func newAccessor(acc interface{}, o *options) (Accessor, error) {
 	if a, ok := acc.(Accessor); ok {
 		return a, nil
 	}
+	if ms, ok := acc.(yaml.MapSlice); ok {
+		return newOrderedMapAccessor(ms, o)
+	}
 
 	rv := reflect.ValueOf(acc)
 	switch rv.Kind() {
 	case reflect.Map:
 		ma := map[string]Accessor{}
+		// The type of the keys is recorded even if all keys are strings,
+		// so that Unwrap returns a map of the same key type.
+		var keys map[string]interface{}
+		if o.stringifyKeys && rv.Type().Key() != stringType {
+			keys = map[string]interface{}{}
+		}
 		for _, k := range rv.MapKeys() {
 			key, ok := k.Interface().(string)
-			if !ok {
+			if !ok && o.stringifyKeys {
+				key, ok = stringifyKey(k.Interface())
+				keys[key] = k.Interface()
+			}
+			if _, dup := ma[key]; !ok || dup {
 				return nil, NewInvalidKeyError(k.Interface())
 			}
-			o, err := NewAccessor(rv.MapIndex(k).Interface())
+			a, err := newChild(rv.MapIndex(k).Interface(), o)
 			if err != nil {
 				return nil, err
 			}
-			ma[key] = o
+			ma[key] = a
 		}
-		return MapAccessor(ma), nil
+		if keys != nil {
+			return withType(KeyedMapAccessor{ma, keys}, rv.Type(), o), nil
+		}
+		return withType(MapAccessor(ma), rv.Type(), o), nil
 	case reflect.Slice:
 		sa := make([]Accessor, rv.Len())
 		for i := 0; i < rv.Len(); i++ {
-			o, err := NewAccessor(rv.Index(i).Interface())
+			a, err := newChild(rv.Index(i).Interface(), o)
 			if err != nil {
 				return nil, err
 			}
-			sa[i] = o
+			sa[i] = a
+		}
+		return withType(SliceAccessor(sa), rv.Type(), o), nil
+	case reflect.Struct:
+		if isOpaque(rv.Type()) {
+			return &ValueAccessor{acc}, nil
 		}
-		return SliceAccessor(sa), nil
+		v := reflect.New(rv.Type()).Elem()
+		v.Set(rv)
+		return newStructAccessor(v, reflect.Value{}, o), nil
+	case reflect.Ptr:
+		if rv.IsNil() || rv.Elem().Kind() != reflect.Struct || isOpaque(rv.Elem().Type()) {
+			return &ValueAccessor{acc}, nil
+		}
+		return newStructAccessor(rv.Elem(), rv, o), nil
 	default:
 		return &ValueAccessor{acc}, nil
 	}
 }
+
+// stringifyKey converts a scalar map key into a string.
+func stringifyKey(key interface{}) (string, bool) {
+	switch reflect.ValueOf(key).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(key), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,188 @@
+package accessor
+
+import (
+	"reflect"
+)
+
+// CloneOption is an option for Clone.
+type CloneOption func(*cloneOptions)
+
+type cloneOptions struct {
+	copyValue func(v interface{}) interface{}
+}
+
+// WithValueCopier replaces the function to copy a value held by a ValueAccessor.
+// By default, []byte is copied and other values are shared,
+// so the function is useful for values like a pointer or a map.
+func WithValueCopier(f func(v interface{}) interface{}) CloneOption {
+	return func(o *cloneOptions) {
+		o.copyValue = f
+	}
+}
+
+// Clone returns a deep copy of the object.
+// MapAccessor, KeyedMapAccessor, OrderedMapAccessor, TypedAccessor, SliceAccessor,
+// StructAccessor and ValueAccessor are copied recursively,
+// and other Accessors are shared because they cannot be copied.
+// A LazyAccessor is converted before being copied.
+// The object held by a StructAccessor or a LiveAccessor is copied deeply through reflection,
+// so the copy no longer refers to the maps, slices and pointers of the original.
+func Clone(acc Accessor, opts ...CloneOption) Accessor {
+	o := &cloneOptions{
+		copyValue: copyValue,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o.clone(acc)
+}
+
+func (o *cloneOptions) clone(acc Accessor) Accessor {
+	switch a := acc.(type) {
+	case *LazyAccessor:
+		return o.clone(a.base())
+	case *TypedAccessor:
+		return &TypedAccessor{o.clone(a.Base), a.Type}
+	case *OrderedMapAccessor:
+		keys := make([]string, len(a.Keys))
+		copy(keys, a.Keys)
+		return &OrderedMapAccessor{o.clone(a.Map).(MapAccessor), keys}
+	case KeyedMapAccessor:
+		keys := make(map[string]interface{}, len(a.Keys))
+		for k, v := range a.Keys {
+			keys[k] = v
+		}
+		return KeyedMapAccessor{o.clone(a.Map).(MapAccessor), keys}
+	case MapAccessor:
+		result := make(MapAccessor, len(a))
+		for k, v := range a {
+			result[k] = o.clone(v)
+		}
+		return result
+	case SliceAccessor:
+		result := make(SliceAccessor, len(a))
+		for i, v := range a {
+			result[i] = o.clone(v)
+		}
+		return result
+	case *StructAccessor:
+		return o.cloneStruct(a)
+	case *LiveAccessor:
+		return o.cloneLive(a)
+	case *ValueAccessor:
+		return &ValueAccessor{o.copyValue(a.Value)}
+	default:
+		return acc
+	}
+}
+
+// cloneStruct copies the struct deeply.
+func (o *cloneOptions) cloneStruct(a *StructAccessor) Accessor {
+	ptr := reflect.New(a.value.Type())
+	ptr.Elem().Set(o.copyReflect(a.value))
+	result := &StructAccessor{ptr.Elem(), reflect.Value{}, a.fields, a.opts}
+	if a.ptr.IsValid() {
+		result.ptr = ptr
+	}
+	return result
+}
+
+// cloneLive copies the object into a new variable of the same type.
+func (o *cloneOptions) cloneLive(a *LiveAccessor) Accessor {
+	src := a.value
+	if a.ptr.IsValid() {
+		src = a.ptr
+	}
+	v := reflect.New(a.typ).Elem()
+	v.Set(pointTo(o.copyReflect(src), a.typ))
+	return newLiveAccessor(v, v.Set)
+}
+
+// pointTo wraps the value by pointers until it can be assigned to the type t,
+// because a LiveAccessor holds only the innermost pointer.
+func pointTo(v reflect.Value, t reflect.Type) reflect.Value {
+	if v.Type().AssignableTo(t) || t.Kind() != reflect.Ptr {
+		return v
+	}
+	p := reflect.New(t.Elem())
+	p.Elem().Set(pointTo(v, t.Elem()))
+	return p
+}
+
+// copyReflect copies the maps, slices, arrays, pointers and exported fields of structs
+// in the value recursively, and copies the other values by copyValue.
+func (o *cloneOptions) copyReflect(rv reflect.Value) reflect.Value {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if rv.IsNil() {
+			return rv
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		result := reflect.New(rv.Type().Elem())
+		result.Elem().Set(o.copyReflect(rv.Elem()))
+		return result
+	case reflect.Interface:
+		result := reflect.New(rv.Type()).Elem()
+		result.Set(o.copyReflect(rv.Elem()))
+		return result
+	case reflect.Map:
+		result := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), o.copyReflect(iter.Value()))
+		}
+		return result
+	case reflect.Slice:
+		result := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			result.Index(i).Set(o.copyReflect(rv.Index(i)))
+		}
+		return result
+	case reflect.Array:
+		result := reflect.New(rv.Type()).Elem()
+		for i := 0; i < rv.Len(); i++ {
+			result.Index(i).Set(o.copyReflect(rv.Index(i)))
+		}
+		return result
+	case reflect.Struct:
+		result := reflect.New(rv.Type()).Elem()
+		result.Set(rv)
+		o.copyFields(result)
+		return result
+	default:
+		if !rv.CanInterface() {
+			return rv
+		}
+		if v, ok := convertValue(o.copyValue(rv.Interface()), rv.Type()); ok {
+			return v
+		}
+		return rv
+	}
+}
+
+// copyFields replaces the fields of the addressable struct with the copies.
+// The fields of an unexported embedded struct are copied in place,
+// and other unexported fields are shared because they cannot be set.
+func (o *cloneOptions) copyFields(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch {
+		case f.CanSet():
+			f.Set(o.copyReflect(f))
+		case v.Type().Field(i).Anonymous && f.Kind() == reflect.Struct:
+			o.copyFields(f)
+		}
+	}
+}
+
+func copyValue(v interface{}) interface{} {
+	if bs, ok := v.([]byte); ok && bs != nil {
+		result := make([]byte, len(bs))
+		copy(result, bs)
+		return result
+	}
+	return v
+}
@@ -0,0 +1,58 @@
+package accessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	assert := assert.New(t)
+
+	acc := MapAccessor(map[string]Accessor{
+		"a": SliceAccessor([]Accessor{
+			&ValueAccessor{1},
+			&ValueAccessor{[]byte("abc")},
+		}),
+		"b": DummyAccessor{1},
+	})
+
+	clone := Clone(acc)
+	assert.Equal(acc, clone)
+
+	clone.(MapAccessor)["a"].(SliceAccessor)[0].(*ValueAccessor).Value = 2
+	clone.(MapAccessor)["a"].(SliceAccessor)[1].(*ValueAccessor).Value.([]byte)[0] = 'x'
+	delete(clone.(MapAccessor), "b")
+
+	assert.Equal(MapAccessor(map[string]Accessor{
+		"a": SliceAccessor([]Accessor{
+			&ValueAccessor{1},
+			&ValueAccessor{[]byte("abc")},
+		}),
+		"b": DummyAccessor{1},
+	}), acc)
+}
+
+func TestClone_WithValueCopier(t *testing.T) {
+	assert := assert.New(t)
+
+	value := map[string]int{"a": 1}
+	acc := SliceAccessor([]Accessor{
+		&ValueAccessor{value},
+	})
+
+	clone := Clone(acc, WithValueCopier(func(v interface{}) interface{} {
+		m, ok := v.(map[string]int)
+		if !ok {
+			return v
+		}
+		result := map[string]int{}
+		for k, v := range m {
+			result[k] = v
+		}
+		return result
+	}))
+	clone.(SliceAccessor)[0].(*ValueAccessor).Value.(map[string]int)["a"] = 2
+
+	assert.Equal(map[string]int{"a": 1}, value)
+}
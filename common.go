@@ -1,9 +1,100 @@
 package accessor
 
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
 type keyPusher interface {
 	PushKey(path string)
 }
 
+// The following interfaces are implemented by the Accessor which may be
+// replaced by the operation, such as a SliceAccessor growing or shrinking.
+// They return the Accessor to be stored again into the parent.
+
+type setter interface {
+	set(path Path, value interface{}) (Accessor, error)
+}
+
+type creator interface {
+	setCreate(path Path, value interface{}) (Accessor, error)
+}
+
+type inserter interface {
+	insert(path Path, value interface{}) (Accessor, error)
+}
+
+type deleter interface {
+	delete(path Path) (Accessor, error)
+}
+
+// wrapper is implemented by the Accessor which wraps a MapAccessor or
+// a SliceAccessor to change how it is unwrapped.
+type wrapper interface {
+	base() Accessor
+}
+
+// baseOf returns the MapAccessor or SliceAccessor wrapped by acc,
+// or acc itself if it is not a wrapper.
+func baseOf(acc Accessor) Accessor {
+	for {
+		w, ok := acc.(wrapper)
+		if !ok {
+			return acc
+		}
+		acc = w.base()
+	}
+}
+
+// shape is the kind of the object listed by lister.
+type shape int
+
+const (
+	shapeValue shape = iota
+	shapeMap
+	shapeSlice
+)
+
+// lister is implemented by the Accessor which has children.
+// It returns the keys of the children in the order to be encoded.
+type lister interface {
+	list() (shape, []string, error)
+}
+
+// listOf returns the shape of the object and the keys of its children.
+// An object which is not a lister is a value.
+func listOf(acc Accessor) (shape, []string, error) {
+	l, ok := acc.(lister)
+	if !ok {
+		l, ok = baseOf(acc).(lister)
+	}
+	if !ok {
+		return shapeValue, nil, nil
+	}
+
+	s, keys, err := l.list()
+	if err != nil {
+		return shapeValue, nil, err
+	}
+	return s, keys, nil
+}
+
+// sortedListOf is same as listOf, but sorts the keys of a map.
+func sortedListOf(acc Accessor) (shape, []string, error) {
+	s, keys, err := listOf(acc)
+	if s == shapeMap {
+		sort.Strings(keys)
+	}
+	return s, keys, err
+}
+
+// childOf returns the child of the key listed by listOf.
+func childOf(acc Accessor, key string) (Accessor, error) {
+	return acc.Get(thePhantomPath.PushKey(key))
+}
+
 func getFromChild(child Accessor, path Path) (Accessor, error) {
 	subPath, ok := path.SubPath()
 	if !ok {
@@ -20,15 +111,91 @@ func getFromChild(child Accessor, path Path) (Accessor, error) {
 	return r, nil
 }
 
-func setToChild(child Accessor, value interface{}, key string, path Path) error {
-	err := child.Set(path, value)
+// setTo sets the value into acc and returns the Accessor which replaces acc.
+func setTo(acc Accessor, path Path, value interface{}) (Accessor, error) {
+	if s, ok := acc.(setter); ok {
+		return s.set(path, value)
+	}
+	return acc, acc.Set(path, value)
+}
+
+// setCreateTo is same as setTo but creates missing objects on the path.
+func setCreateTo(acc Accessor, path Path, value interface{}) (Accessor, error) {
+	if c, ok := acc.(creator); ok {
+		return c.setCreate(path, value)
+	}
+	return acc, acc.Set(path, value)
+}
+
+// insertTo inserts the value into acc and returns the Accessor which replaces acc.
+func insertTo(acc Accessor, path Path, value interface{}) (Accessor, error) {
+	if i, ok := acc.(inserter); ok {
+		return i.insert(path, value)
+	}
+	return nil, NewNoSuchPathError(fmt.Sprintf("cannot insert into %T", acc), path.Key())
+}
+
+// deleteFrom deletes the value from acc and returns the Accessor which replaces acc.
+func deleteFrom(acc Accessor, path Path) (Accessor, error) {
+	if d, ok := acc.(deleter); ok {
+		return d.delete(path)
+	}
+	return acc, acc.Delete(path)
+}
+
+func setToChild(child Accessor, value interface{}, key string, path Path) (Accessor, error) {
+	child, err := setTo(child, path, value)
 	if err != nil {
 		if pe, ok := err.(keyPusher); ok {
 			pe.PushKey(key)
 		}
-		return err
+		return nil, err
+	}
+	return child, nil
+}
+
+func setCreateToChild(child Accessor, value interface{}, key string, path Path) (Accessor, error) {
+	child, err := setCreateTo(child, path, value)
+	if err != nil {
+		if pe, ok := err.(keyPusher); ok {
+			pe.PushKey(key)
+		}
+		return nil, err
+	}
+	return child, nil
+}
+
+// newContainer creates an empty object which can hold the key.
+func newContainer(key string) Accessor {
+	if key == "-" {
+		return SliceAccessor{}
+	}
+	if i, err := strconv.Atoi(key); err == nil && i >= 0 {
+		return SliceAccessor{}
+	}
+	return MapAccessor{}
+}
+
+func insertToChild(child Accessor, value interface{}, key string, path Path) (Accessor, error) {
+	child, err := insertTo(child, path, value)
+	if err != nil {
+		if pe, ok := err.(keyPusher); ok {
+			pe.PushKey(key)
+		}
+		return nil, err
+	}
+	return child, nil
+}
+
+func deleteFromChild(child Accessor, key string, path Path) (Accessor, error) {
+	child, err := deleteFrom(child, path)
+	if err != nil {
+		if pe, ok := err.(keyPusher); ok {
+			pe.PushKey(key)
+		}
+		return nil, err
 	}
-	return nil
+	return child, nil
 }
 
 func foreach(child Accessor, key string, f func(Path, interface{}) error) error {
@@ -0,0 +1,164 @@
+package accessor
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// ChangeType is a kind of Change.
+type ChangeType int
+
+// ChangeTypes.
+const (
+	ChangeAdded ChangeType = iota
+	ChangeRemoved
+	ChangeModified
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	default:
+		return "unknown"
+	}
+}
+
+// Change is a difference between two objects.
+// Old is nil for ChangeAdded, and New is nil for ChangeRemoved.
+type Change struct {
+	Type ChangeType
+	Path Path
+	Old  interface{}
+	New  interface{}
+}
+
+// Diff returns changes from a to b, recursing through maps and slices,
+// including structs and the objects of LiveAccessor.
+// The changes are ordered to be applied sequentially, that is,
+// removed elements of a slice are listed from the last index.
+func Diff(a, b Accessor) []Change {
+	as, akeys, aerr := listOf(a)
+	bs, bkeys, berr := listOf(b)
+	if aerr == nil && berr == nil && as == bs && as != shapeValue {
+		ac, aok := childrenOf(a, akeys)
+		bc, bok := childrenOf(b, bkeys)
+		if aok && bok {
+			if as == shapeMap {
+				return diffMap(keyed(akeys, ac), keyed(bkeys, bc))
+			}
+			return diffSlice(ac, bc)
+		}
+	}
+
+	if reflect.DeepEqual(a.Unwrap(), b.Unwrap()) {
+		return nil
+	}
+	return []Change{{ChangeModified, thePhantomPath, a.Unwrap(), b.Unwrap()}}
+}
+
+// childrenOf returns the children of the keys in the same order,
+// or false if any of them cannot be got.
+func childrenOf(acc Accessor, keys []string) ([]Accessor, bool) {
+	children := make([]Accessor, len(keys))
+	for i, k := range keys {
+		child, err := childOf(acc, k)
+		if err != nil {
+			return nil, false
+		}
+		children[i] = child
+	}
+	return children, true
+}
+
+func keyed(keys []string, children []Accessor) map[string]Accessor {
+	m := make(map[string]Accessor, len(keys))
+	for i, k := range keys {
+		m[k] = children[i]
+	}
+	return m
+}
+
+func diffMap(a, b map[string]Accessor) []Change {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []Change
+	for _, k := range keys {
+		av, aok := a[k]
+		bv, bok := b[k]
+		switch {
+		case !bok:
+			changes = append(changes, Change{ChangeRemoved, thePhantomPath.PushKey(k), av.Unwrap(), nil})
+		case !aok:
+			changes = append(changes, Change{ChangeAdded, thePhantomPath.PushKey(k), nil, bv.Unwrap()})
+		default:
+			changes = append(changes, diffChild(av, bv, k)...)
+		}
+	}
+	return changes
+}
+
+func diffSlice(a, b []Accessor) []Change {
+	var changes []Change
+	for i := 0; i < len(a) && i < len(b); i++ {
+		changes = append(changes, diffChild(a[i], b[i], strconv.Itoa(i))...)
+	}
+	for i := len(a); i < len(b); i++ {
+		changes = append(changes, Change{ChangeAdded, thePhantomPath.PushKey(strconv.Itoa(i)), nil, b[i].Unwrap()})
+	}
+	for i := len(a) - 1; i >= len(b); i-- {
+		changes = append(changes, Change{ChangeRemoved, thePhantomPath.PushKey(strconv.Itoa(i)), a[i].Unwrap(), nil})
+	}
+	return changes
+}
+
+func diffChild(a, b Accessor, key string) []Change {
+	changes := Diff(a, b)
+	for i := range changes {
+		changes[i].Path = changes[i].Path.PushKey(key)
+	}
+	return changes
+}
+
+// CreatePatch creates a RFC 6902 JSON Patch which transforms a into b.
+func CreatePatch(a, b Accessor) ([]byte, error) {
+	changes := Diff(a, b)
+	ops := make([]patchOperation, 0, len(changes))
+	for _, c := range changes {
+		pointer := c.Path.JSONPointer()
+		op := patchOperation{Path: &pointer}
+		switch c.Type {
+		case ChangeAdded:
+			op.Op = "add"
+		case ChangeRemoved:
+			op.Op = "remove"
+		case ChangeModified:
+			op.Op = "replace"
+		}
+
+		if c.Type != ChangeRemoved {
+			value, err := json.Marshal(c.New)
+			if err != nil {
+				return nil, err
+			}
+			op.Value = value
+		}
+		ops = append(ops, op)
+	}
+	return json.Marshal(ops)
+}
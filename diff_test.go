@@ -0,0 +1,166 @@
+package accessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	type Input struct {
+		A string
+		B string
+	}
+	type Expect struct {
+		Changes []Change
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "no change",
+			Input: Input{
+				A: `{"a": {"b": [1, 2]}}`,
+				B: `{"a": {"b": [1, 2]}}`,
+			},
+			Expect: Expect{
+				Changes: nil,
+			},
+		},
+		{
+			Title: "map",
+			Input: Input{
+				A: `{"a": 1, "b": {"c": 2}, "d": 3}`,
+				B: `{"a": 1, "b": {"c": 4}, "e": 5}`,
+			},
+			Expect: Expect{
+				Changes: []Change{
+					{ChangeModified, newPath("b", "c"), float64(2), float64(4)},
+					{ChangeRemoved, newPath("d"), float64(3), nil},
+					{ChangeAdded, newPath("e"), nil, float64(5)},
+				},
+			},
+		},
+		{
+			Title: "slice",
+			Input: Input{
+				A: `{"a": [1, 2, 3], "b": [1]}`,
+				B: `{"a": [1, 4], "b": [1, 2, 3]}`,
+			},
+			Expect: Expect{
+				Changes: []Change{
+					{ChangeModified, newPath("a", "1"), float64(2), float64(4)},
+					{ChangeRemoved, newPath("a", "2"), float64(3), nil},
+					{ChangeAdded, newPath("b", "1"), nil, float64(2)},
+					{ChangeAdded, newPath("b", "2"), nil, float64(3)},
+				},
+			},
+		},
+		{
+			Title: "type changed",
+			Input: Input{
+				A: `{"a": [1]}`,
+				B: `{"a": {"0": 1}}`,
+			},
+			Expect: Expect{
+				Changes: []Change{
+					{ChangeModified, newPath("a"), []interface{}{float64(1)}, map[string]interface{}{"0": float64(1)}},
+				},
+			},
+		},
+		{
+			Title: "root",
+			Input: Input{
+				A: `1`,
+				B: `"a"`,
+			},
+			Expect: Expect{
+				Changes: []Change{
+					{ChangeModified, thePhantomPath, float64(1), "a"},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			a := mustJSONAccessor(t, testCase.Input.A)
+			b := mustJSONAccessor(t, testCase.Input.B)
+
+			assert.Equal(testCase.Expect.Changes, Diff(a, b))
+		})
+	}
+}
+
+func TestCreatePatch(t *testing.T) {
+	type Input struct {
+		A string
+		B string
+	}
+	type Expect struct {
+		Patch string
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "changes",
+			Input: Input{
+				A: `{"a": [1, 2, 3], "b": {"c": 1}, "d": null}`,
+				B: `{"a": [4], "b": {"c": 1, "e": [5]}}`,
+			},
+			Expect: Expect{
+				Patch: `[` +
+					`{"op":"replace","path":"/a/0","value":4},` +
+					`{"op":"remove","path":"/a/2"},` +
+					`{"op":"remove","path":"/a/1"},` +
+					`{"op":"add","path":"/b/e","value":[5]},` +
+					`{"op":"remove","path":"/d"}` +
+					`]`,
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			a := mustJSONAccessor(t, testCase.Input.A)
+			b := mustJSONAccessor(t, testCase.Input.B)
+
+			patch, err := CreatePatch(a, b)
+			assert.Nil(err)
+			assert.Equal(testCase.Expect.Patch, string(patch))
+
+			err = ApplyPatch(a, patch)
+			assert.Nil(err)
+			assert.Equal(b.Unwrap(), a.Unwrap())
+		})
+	}
+}
+
+func TestDiff_Struct(t *testing.T) {
+	assert := assert.New(t)
+
+	a := mustAccessor(t, &testConfig{Name: "x", Ports: []int{80}})
+	b := mustAccessor(t, &testConfig{Name: "y", Ports: []int{80, 443}})
+
+	assert.Equal([]Change{
+		{ChangeModified, newPath("name"), "x", "y"},
+		{ChangeAdded, newPath("ports", "1"), nil, 443},
+	}, Diff(a, b))
+
+	obj := map[string]interface{}{"a": []int{1, 2}}
+	live := mustLiveAccessor(t, &obj)
+	assert.Nil(Diff(live, mustAccessor(t, map[string]interface{}{"a": []interface{}{1, 2}})))
+}
@@ -19,6 +19,11 @@ func (a DummyAccessor) Set(path Path, _ interface{}) error {
 	return fmt.Errorf("this is dummy accessor: %d", a.ID)
 }
 
+// Delete implements Accessor.
+func (a DummyAccessor) Delete(path Path) error {
+	return fmt.Errorf("this is dummy accessor: %d", a.ID)
+}
+
 // Unwrap implements Accessor.
 func (a DummyAccessor) Unwrap() interface{} {
 	return a.ID
@@ -0,0 +1,225 @@
+package accessor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// EqualOption is an option for Equal.
+type EqualOption func(*equalOptions)
+
+type equalOptions struct {
+	numeric   bool
+	tolerance float64
+}
+
+// IgnoreNumericTypes compares numbers by the value regardless of the type,
+// so that int from YAML and float64 from JSON can be equal.
+// json.Number is also treated as a number.
+func IgnoreNumericTypes() EqualOption {
+	return func(o *equalOptions) {
+		o.numeric = true
+	}
+}
+
+// FloatTolerance compares numbers like IgnoreNumericTypes,
+// and treats them as equal when the difference is within the epsilon.
+func FloatTolerance(epsilon float64) EqualOption {
+	return func(o *equalOptions) {
+		o.numeric = true
+		o.tolerance = epsilon
+	}
+}
+
+// Equal reports whether a and b are deeply equal.
+// Maps and slices are compared recursively, and other values are compared
+// by reflect.DeepEqual unless the options say otherwise.
+func Equal(a, b Accessor, opts ...EqualOption) bool {
+	o := &equalOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o.equal(a, b)
+}
+
+func (o *equalOptions) equal(a, b Accessor) bool {
+	switch av := baseOf(a).(type) {
+	case MapAccessor:
+		if bv, ok := baseOf(b).(MapAccessor); ok {
+			if len(av) != len(bv) {
+				return false
+			}
+			for k, v := range av {
+				w, ok := bv[k]
+				if !ok || !o.equal(v, w) {
+					return false
+				}
+			}
+			return true
+		}
+	case SliceAccessor:
+		if bv, ok := baseOf(b).(SliceAccessor); ok {
+			if len(av) != len(bv) {
+				return false
+			}
+			for i := range av {
+				if !o.equal(av[i], bv[i]) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return o.equalListed(a, b)
+}
+
+// equalListed compares the objects through lister,
+// such as a StructAccessor or a LiveAccessor with a MapAccessor.
+func (o *equalOptions) equalListed(a, b Accessor) bool {
+	as, akeys, err := listOf(a)
+	if err != nil {
+		return false
+	}
+	bs, bkeys, err := listOf(b)
+	if err != nil || as != bs || len(akeys) != len(bkeys) {
+		return false
+	}
+	if as == shapeValue {
+		return o.equalValue(a.Unwrap(), b.Unwrap())
+	}
+
+	for _, k := range akeys {
+		ac, err := childOf(a, k)
+		if err != nil {
+			return false
+		}
+		bc, err := childOf(b, k)
+		if err != nil || !o.equal(ac, bc) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *equalOptions) equalValue(a, b interface{}) bool {
+	if o.numeric {
+		an, aok := toNumber(a)
+		bn, bok := toNumber(b)
+		if aok && bok {
+			if o.tolerance > 0 {
+				af, _ := an.Float64()
+				bf, _ := bn.Float64()
+				return math.Abs(af-bf) <= o.tolerance
+			}
+			return an.Cmp(bn) == 0
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// toNumber converts the value into a exact number if it is a number.
+// json.Number is exact if it is an integer, and otherwise is parsed as float64
+// like encoding/json does, so that it equals the float64 decoded from the same text.
+func toNumber(v interface{}) (*big.Float, bool) {
+	if n, ok := v.(json.Number); ok {
+		if i, ok := new(big.Int).SetString(string(n), 10); ok {
+			return new(big.Float).SetInt(i), true
+		}
+		f, err := strconv.ParseFloat(string(n), 64)
+		if err != nil || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return new(big.Float).SetFloat64(f), true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(rv.Float()) {
+			return nil, false
+		}
+		return new(big.Float).SetFloat64(rv.Float()), true
+	default:
+		return nil, false
+	}
+}
+
+// Hash returns a SHA-256 hash of the canonical encoding of the object.
+// Map keys are sorted and numbers are encoded regardless of the type,
+// so objects which are Equal with IgnoreNumericTypes have the same hash.
+func Hash(acc Accessor) [32]byte {
+	buf := &bytes.Buffer{}
+	writeCanonical(buf, acc)
+	return sha256.Sum256(buf.Bytes())
+}
+
+func writeCanonical(buf *bytes.Buffer, acc Accessor) {
+	s, keys, err := sortedListOf(acc)
+	if err != nil || s == shapeValue {
+		writeCanonicalValue(buf, acc.Unwrap())
+		return
+	}
+
+	begin, end := byte('{'), byte('}')
+	if s == shapeSlice {
+		begin, end = '[', ']'
+	}
+	buf.WriteByte(begin)
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if s == shapeMap {
+			buf.WriteString(strconv.Quote(k))
+			buf.WriteByte(':')
+		}
+		child, err := childOf(acc, k)
+		if err != nil {
+			writeCanonicalValue(buf, nil)
+			continue
+		}
+		writeCanonical(buf, child)
+	}
+	buf.WriteByte(end)
+}
+
+func writeCanonicalValue(buf *bytes.Buffer, v interface{}) {
+	if n, ok := toNumber(v); ok {
+		buf.WriteString(n.Text('g', -1))
+		return
+	}
+
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case string:
+		buf.WriteString(strconv.Quote(v))
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			fmt.Fprintf(buf, "%#v", v)
+			return
+		}
+		buf.WriteString(strconv.Quote(string(text)))
+	default:
+		bs, err := json.Marshal(v)
+		if err != nil {
+			fmt.Fprintf(buf, "%#v", v)
+			return
+		}
+		buf.Write(bs)
+	}
+}
@@ -0,0 +1,165 @@
+package accessor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	type Input struct {
+		A       Accessor
+		B       Accessor
+		Options []EqualOption
+	}
+	type Expect struct {
+		Equal bool
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "equal",
+			Input: Input{
+				A: MapAccessor(map[string]Accessor{
+					"a": SliceAccessor([]Accessor{&ValueAccessor{1}, &ValueAccessor{"x"}}),
+				}),
+				B: MapAccessor(map[string]Accessor{
+					"a": SliceAccessor([]Accessor{&ValueAccessor{1}, &ValueAccessor{"x"}}),
+				}),
+			},
+			Expect: Expect{
+				Equal: true,
+			},
+		},
+		{
+			Title: "different key",
+			Input: Input{
+				A: MapAccessor(map[string]Accessor{"a": &ValueAccessor{1}}),
+				B: MapAccessor(map[string]Accessor{"b": &ValueAccessor{1}}),
+			},
+			Expect: Expect{
+				Equal: false,
+			},
+		},
+		{
+			Title: "different length",
+			Input: Input{
+				A: SliceAccessor([]Accessor{&ValueAccessor{1}}),
+				B: SliceAccessor([]Accessor{&ValueAccessor{1}, &ValueAccessor{1}}),
+			},
+			Expect: Expect{
+				Equal: false,
+			},
+		},
+		{
+			Title: "different type",
+			Input: Input{
+				A: SliceAccessor([]Accessor{}),
+				B: &ValueAccessor{[]interface{}{}},
+			},
+			Expect: Expect{
+				Equal: false,
+			},
+		},
+		{
+			Title: "numeric type",
+			Input: Input{
+				A: &ValueAccessor{1},
+				B: &ValueAccessor{float64(1)},
+			},
+			Expect: Expect{
+				Equal: false,
+			},
+		},
+		{
+			Title: "ignore numeric types",
+			Input: Input{
+				A: SliceAccessor([]Accessor{&ValueAccessor{1}, &ValueAccessor{uint8(2)}, &ValueAccessor{json.Number("3")}}),
+				B: SliceAccessor([]Accessor{&ValueAccessor{float64(1)}, &ValueAccessor{int64(2)}, &ValueAccessor{float32(3)}}),
+				Options: []EqualOption{
+					IgnoreNumericTypes(),
+				},
+			},
+			Expect: Expect{
+				Equal: true,
+			},
+		},
+		{
+			Title: "fractional json.Number",
+			Input: Input{
+				A: SliceAccessor([]Accessor{&ValueAccessor{json.Number("0.1")}, &ValueAccessor{json.Number("1e400")}}),
+				B: SliceAccessor([]Accessor{&ValueAccessor{0.1}, &ValueAccessor{json.Number("1e400")}}),
+				Options: []EqualOption{
+					IgnoreNumericTypes(),
+				},
+			},
+			Expect: Expect{
+				Equal: true,
+			},
+		},
+		{
+			Title: "large integer json.Number",
+			Input: Input{
+				A: &ValueAccessor{json.Number("9007199254740993")},
+				B: &ValueAccessor{float64(9007199254740993)},
+				Options: []EqualOption{
+					IgnoreNumericTypes(),
+				},
+			},
+			Expect: Expect{
+				Equal: false,
+			},
+		},
+		{
+			Title: "float tolerance",
+			Input: Input{
+				A: &ValueAccessor{0.1 + 0.2},
+				B: &ValueAccessor{0.3},
+				Options: []EqualOption{
+					FloatTolerance(1e-9),
+				},
+			},
+			Expect: Expect{
+				Equal: true,
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			assert.Equal(testCase.Expect.Equal, Equal(testCase.Input.A, testCase.Input.B, testCase.Input.Options...))
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	assert := assert.New(t)
+
+	a := MapAccessor(map[string]Accessor{
+		"a": SliceAccessor([]Accessor{&ValueAccessor{1}, &ValueAccessor{"x"}}),
+		"b": &ValueAccessor{nil},
+		"c": &ValueAccessor{true},
+	})
+	b := MapAccessor(map[string]Accessor{
+		"c": &ValueAccessor{true},
+		"b": &ValueAccessor{nil},
+		"a": SliceAccessor([]Accessor{&ValueAccessor{float64(1)}, &ValueAccessor{"x"}}),
+	})
+	c := MapAccessor(map[string]Accessor{
+		"a": SliceAccessor([]Accessor{&ValueAccessor{"1"}, &ValueAccessor{"x"}}),
+		"b": &ValueAccessor{nil},
+		"c": &ValueAccessor{true},
+	})
+
+	assert.Equal(Hash(a), Hash(b))
+	assert.NotEqual(Hash(a), Hash(c))
+	assert.Equal(Hash(&ValueAccessor{json.Number("0.1")}), Hash(&ValueAccessor{0.1}))
+}
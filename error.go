@@ -2,6 +2,8 @@ package accessor
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 )
 
 // NewNoSuchPathError creates a NoSuchPathError.
@@ -56,3 +58,72 @@ type InvalidPathError struct {
 func (e *InvalidPathError) Error() string {
 	return fmt.Sprintf("path is invalid: %s", e.Message)
 }
+
+// NewInvalidPatchError creates a InvalidPatchError.
+func NewInvalidPatchError(message string) error {
+	return &InvalidPatchError{message}
+}
+
+// InvalidPatchError is returned when a patch is malformed or cannot be applied.
+type InvalidPatchError struct {
+	Message string
+}
+
+func (e *InvalidPatchError) Error() string {
+	return fmt.Sprintf("patch is invalid: %s", e.Message)
+}
+
+// NewTestFailedError creates a TestFailedError.
+func NewTestFailedError(path Path, expected, actual interface{}) error {
+	return &TestFailedError{path, expected, actual}
+}
+
+// TestFailedError is returned when a test operation of a patch failed.
+type TestFailedError struct {
+	Path     Path
+	Expected interface{}
+	Actual   interface{}
+}
+
+func (e *TestFailedError) Error() string {
+	return fmt.Sprintf("test failed: expected %v but got %v: at %s", e.Expected, e.Actual, e.Path)
+}
+
+// NewTypeMismatchError creates a TypeMismatchError.
+func NewTypeMismatchError(value interface{}, typ reflect.Type, path Path) *TypeMismatchError {
+	return &TypeMismatchError{value, typ, path}
+}
+
+// TypeMismatchError is returned when a value cannot be used as the type.
+type TypeMismatchError struct {
+	Value interface{}
+	Type  reflect.Type
+	Path  Path
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("cannot use %T(%v) as %s: at %s", e.Value, e.Value, e.Type, e.Path)
+}
+
+// PushKey push key to the head of the stack trace.
+func (e *TypeMismatchError) PushKey(key string) {
+	e.Path = e.Path.PushKey(key)
+}
+
+// NewDecodeError creates a DecodeError.
+func NewDecodeError(errs []error) error {
+	return &DecodeError{errs}
+}
+
+// DecodeError is returned when some values cannot be decoded by Decode.
+type DecodeError struct {
+	Errors []error
+}
+
+func (e *DecodeError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d error(s) decoding: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
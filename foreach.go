@@ -0,0 +1,30 @@
+package accessor
+
+// ForeachSorted enumerates all value in the object like Accessor.Foreach,
+// but visits the keys of maps and the fields of structs in the lexicographical order
+// and the elements of slices in the order of the index, so that the order is reproducible.
+// An Accessor other than the built-in ones is enumerated by its Foreach.
+func ForeachSorted(acc Accessor, f func(path Path, value interface{}) error) error {
+	s, keys, err := sortedListOf(acc)
+	if err != nil {
+		return err
+	}
+	if s == shapeValue {
+		return acc.Foreach(f)
+	}
+
+	for _, k := range keys {
+		child, err := childOf(acc, k)
+		if err != nil {
+			return err
+		}
+		key := k
+		err = ForeachSorted(child, func(path Path, value interface{}) error {
+			return f(path.PushKey(key), value)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
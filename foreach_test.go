@@ -0,0 +1,87 @@
+package accessor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForeachSorted(t *testing.T) {
+	type Input struct {
+		Accessor Accessor
+	}
+	type Expect struct {
+		Paths  []string
+		Values []interface{}
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "map and slice",
+			Input: Input{
+				Accessor: MapAccessor{
+					"c": &ValueAccessor{1},
+					"a": SliceAccessor{
+						MapAccessor{"y": &ValueAccessor{2}, "x": &ValueAccessor{3}},
+						&ValueAccessor{4},
+					},
+					"b": MapAccessor{},
+				},
+			},
+			Expect: Expect{
+				Paths:  []string{"a/0/x", "a/0/y", "a/1", "c"},
+				Values: []interface{}{3, 2, 4, 1},
+			},
+		},
+		{
+			Title: "struct",
+			Input: Input{
+				Accessor: mustAccessor(t, struct {
+					B int
+					A []string
+				}{1, []string{"x"}}),
+			},
+			Expect: Expect{
+				Paths:  []string{"A/0", "B"},
+				Values: []interface{}{"x", 1},
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var paths []string
+			var values []interface{}
+			err := ForeachSorted(testCase.Input.Accessor, func(path Path, value interface{}) error {
+				paths = append(paths, path.String())
+				values = append(values, value)
+				return nil
+			})
+			assert.Nil(err)
+			assert.Equal(testCase.Expect.Paths, paths)
+			assert.Equal(testCase.Expect.Values, values)
+		})
+	}
+}
+
+func TestForeachSorted_Error(t *testing.T) {
+	assert := assert.New(t)
+
+	acc := MapAccessor{"a": &ValueAccessor{1}, "b": &ValueAccessor{2}}
+	expected := errors.New("stop")
+	var paths []string
+	err := ForeachSorted(acc, func(path Path, value interface{}) error {
+		paths = append(paths, path.String())
+		return expected
+	})
+	assert.Equal(expected, err)
+	assert.Equal([]string{"a"}, paths)
+}
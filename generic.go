@@ -0,0 +1,52 @@
+package accessor
+
+import (
+	"reflect"
+)
+
+// GetAs finds the value by the path and decodes it into the type T.
+// T can be any type such as a struct or a slice of structs.
+// The fields of a struct are matched with the keys like StructAccessor,
+// or case-insensitively if no field has the exact name, and the unknown keys are ignored.
+// A string is decoded into time.Duration by time.ParseDuration and into the type
+// implementing encoding.TextUnmarshaler such as time.Time.
+// If T is Accessor, the Accessor found by the path is returned as is.
+// TypeMismatchError is returned with the path of the value which cannot be decoded.
+func GetAs[T any](acc Accessor, path Path) (T, error) {
+	var result T
+	child, err := acc.Get(path)
+	if err != nil {
+		return result, err
+	}
+	err = decodeAs(child, path, &result)
+	return result, err
+}
+
+// Lookup is same as GetAs, but returns false instead of an error
+// when the path is absent, such as a missing key or an index out of range.
+// A null is present and decoded into T, so use a pointer type or Accessor to accept it.
+// An error is returned when the path is invalid for the object, such as a key of a string.
+// Lookup[Accessor] returns the Accessor found by the path as is.
+func Lookup[T any](acc Accessor, path Path) (T, bool, error) {
+	var result T
+	child, ok, err := lookup(acc, path)
+	if err != nil || !ok {
+		return result, false, err
+	}
+	err = decodeAs(child, path, &result)
+	if err != nil {
+		return result, false, err
+	}
+	return result, true, nil
+}
+
+func decodeAs(acc Accessor, path Path, ptr interface{}) error {
+	d := &decoder{}
+	rv := reflect.ValueOf(ptr).Elem()
+	v := reflect.New(rv.Type()).Elem()
+	if err := d.decode(acc, v, path); err != nil {
+		return err
+	}
+	rv.Set(v)
+	return nil
+}
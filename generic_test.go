@@ -0,0 +1,94 @@
+package accessor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAs(t *testing.T) {
+	assert := assert.New(t)
+
+	acc, err := FromJSON(strings.NewReader(`{
+		"config": {
+			"name": "app",
+			"version": 2,
+			"server": {"host": "localhost", "port": 80},
+			"backup": {"host": "backup", "port": 8080},
+			"labels": {"a": "b"},
+			"ports": [80, 443],
+			"created": "2018-01-02T03:04:05Z",
+			"unknown": true
+		},
+		"servers": [
+			{"host": "a", "port": 1},
+			{"HOST": "b", "port": 2}
+		],
+		"timeouts": {"read": "1s", "write": "2s"},
+		"invalid": [{"host": "a", "port": "x"}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := GetAs[testConfig](acc, newPath("config"))
+	assert.Nil(err)
+	assert.Equal(testConfig{
+		testMeta: testMeta{2},
+		Name:     "app",
+		Server:   testServer{"localhost", 80},
+		Backup:   &testServer{"backup", 8080},
+		Labels:   map[string]string{"a": "b"},
+		Ports:    []int{80, 443},
+		Created:  time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, config)
+
+	servers, err := GetAs[[]testServer](acc, newPath("servers"))
+	assert.Nil(err)
+	assert.Equal([]testServer{{"a", 1}, {"b", 2}}, servers)
+
+	timeouts, err := GetAs[map[string]time.Duration](acc, newPath("timeouts"))
+	assert.Nil(err)
+	assert.Equal(map[string]time.Duration{"read": time.Second, "write": 2 * time.Second}, timeouts)
+
+	port, err := GetAs[int](acc, newPath("servers", "0", "port"))
+	assert.Nil(err)
+	assert.Equal(1, port)
+
+	sub, err := GetAs[Accessor](acc, newPath("servers", "0"))
+	assert.Nil(err)
+	assert.Equal(MapAccessor{"host": &ValueAccessor{"a"}, "port": &ValueAccessor{float64(1)}}, sub)
+
+	_, err = GetAs[[]testServer](acc, newPath("invalid"))
+	assert.Equal(NewTypeMismatchError("x", reflect.TypeOf(0), newPath("invalid", "0", "port")), err)
+
+	_, err = GetAs[[]int](acc, newPath("servers", "0"))
+	assert.Equal(NewTypeMismatchError(map[string]interface{}{"host": "a", "port": float64(1)}, reflect.TypeOf([]int{}), newPath("servers", "0")), err)
+
+	_, err = GetAs[string](acc, newPath("missing"))
+	assert.Equal(NewNoSuchPathError("no such key", "missing"), err)
+}
+
+func TestLookup(t *testing.T) {
+	assert := assert.New(t)
+
+	acc := mustAccessor(t, map[string]interface{}{"a": 1})
+
+	v, ok, err := Lookup[int](acc, newPath("a"))
+	assert.Nil(err)
+	assert.True(ok)
+	assert.Equal(1, v)
+
+	v, ok, err = Lookup[int](acc, newPath("b"))
+	assert.Nil(err)
+	assert.False(ok)
+	assert.Equal(0, v)
+
+	s, ok, err := Lookup[string](acc, newPath("a"))
+	assert.Equal(NewTypeMismatchError(1, reflect.TypeOf(""), newPath("a")), err)
+	assert.False(ok)
+	assert.Equal("", s)
+}
@@ -0,0 +1,137 @@
+package accessor
+
+import (
+	"reflect"
+	"time"
+)
+
+var (
+	stringType      = reflect.TypeOf("")
+	int64Type       = reflect.TypeOf(int64(0))
+	float64Type     = reflect.TypeOf(float64(0))
+	boolType        = reflect.TypeOf(false)
+	durationType    = reflect.TypeOf(time.Duration(0))
+	timeType        = reflect.TypeOf(time.Time{})
+	stringSliceType = reflect.TypeOf([]string(nil))
+	stringMapType   = reflect.TypeOf(map[string]interface{}(nil))
+)
+
+// GetString finds a string by the path.
+// TypeMismatchError is returned when the value is not a string.
+func GetString(acc Accessor, path Path) (string, error) {
+	rv, err := getConverted(acc, path, stringType)
+	if err != nil {
+		return "", err
+	}
+	return rv.String(), nil
+}
+
+// GetInt64 finds an integer by the path.
+// A number of any type, such as float64 decoded from JSON, is converted
+// if it can be represented by int64 without loss.
+func GetInt64(acc Accessor, path Path) (int64, error) {
+	rv, err := getConverted(acc, path, int64Type)
+	if err != nil {
+		return 0, err
+	}
+	return rv.Int(), nil
+}
+
+// GetFloat64 finds a number by the path.
+// An integer is converted if it can be represented by float64 without loss.
+func GetFloat64(acc Accessor, path Path) (float64, error) {
+	rv, err := getConverted(acc, path, float64Type)
+	if err != nil {
+		return 0, err
+	}
+	return rv.Float(), nil
+}
+
+// GetBool finds a bool by the path.
+func GetBool(acc Accessor, path Path) (bool, error) {
+	rv, err := getConverted(acc, path, boolType)
+	if err != nil {
+		return false, err
+	}
+	return rv.Bool(), nil
+}
+
+// GetDuration finds a duration by the path.
+// A string is parsed by time.ParseDuration, and an integer is treated as nanoseconds.
+func GetDuration(acc Accessor, path Path) (time.Duration, error) {
+	v, err := getValue(acc, path)
+	if err != nil {
+		return 0, err
+	}
+	if s, ok := v.(string); ok {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, NewTypeMismatchError(v, durationType, path)
+		}
+		return d, nil
+	}
+	rv, ok := convertValue(v, durationType)
+	if !ok {
+		return 0, NewTypeMismatchError(v, durationType, path)
+	}
+	return time.Duration(rv.Int()), nil
+}
+
+// GetTime finds a time by the path.
+// A string is parsed in the RFC 3339 format.
+func GetTime(acc Accessor, path Path) (time.Time, error) {
+	v, err := getValue(acc, path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case string:
+		result, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return time.Time{}, NewTypeMismatchError(v, timeType, path)
+		}
+		return result, nil
+	default:
+		return time.Time{}, NewTypeMismatchError(v, timeType, path)
+	}
+}
+
+// GetStringSlice finds a slice of strings by the path.
+func GetStringSlice(acc Accessor, path Path) ([]string, error) {
+	rv, err := getConverted(acc, path, stringSliceType)
+	if err != nil {
+		return nil, err
+	}
+	return rv.Interface().([]string), nil
+}
+
+// GetStringMap finds a map by the path.
+func GetStringMap(acc Accessor, path Path) (map[string]interface{}, error) {
+	rv, err := getConverted(acc, path, stringMapType)
+	if err != nil {
+		return nil, err
+	}
+	return rv.Interface().(map[string]interface{}), nil
+}
+
+func getValue(acc Accessor, path Path) (interface{}, error) {
+	child, err := acc.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	return child.Unwrap(), nil
+}
+
+func getConverted(acc Accessor, path Path, t reflect.Type) (reflect.Value, error) {
+	v, err := getValue(acc, path)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	rv, ok := convertValue(v, t)
+	if !ok || v == nil {
+		return reflect.Value{}, NewTypeMismatchError(v, t, path)
+	}
+	return rv, nil
+}
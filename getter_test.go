@@ -0,0 +1,228 @@
+package accessor
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetters(t *testing.T) {
+	acc, err := FromJSON(strings.NewReader(`{
+		"name": "hello",
+		"port": 8080,
+		"ratio": 0.5,
+		"enabled": true,
+		"timeout": "1m30s",
+		"interval": 1000,
+		"created": "2018-01-02T03:04:05Z",
+		"tags": ["a", "b"],
+		"labels": {"a": "b"},
+		"mixed": ["a", 1],
+		"null": null
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type Input struct {
+		Path   string
+		Getter func(acc Accessor, path Path) (interface{}, error)
+	}
+	type Expect struct {
+		Value interface{}
+		Err   error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	getString := func(acc Accessor, path Path) (interface{}, error) { return GetString(acc, path) }
+	getInt64 := func(acc Accessor, path Path) (interface{}, error) { return GetInt64(acc, path) }
+	getFloat64 := func(acc Accessor, path Path) (interface{}, error) { return GetFloat64(acc, path) }
+	getBool := func(acc Accessor, path Path) (interface{}, error) { return GetBool(acc, path) }
+	getDuration := func(acc Accessor, path Path) (interface{}, error) { return GetDuration(acc, path) }
+	getTime := func(acc Accessor, path Path) (interface{}, error) { return GetTime(acc, path) }
+	getStringSlice := func(acc Accessor, path Path) (interface{}, error) { return GetStringSlice(acc, path) }
+	getStringMap := func(acc Accessor, path Path) (interface{}, error) { return GetStringMap(acc, path) }
+
+	table := []Test{
+		{
+			Title: "string",
+			Input: Input{"/name", getString},
+			Expect: Expect{
+				Value: "hello",
+				Err:   nil,
+			},
+		},
+		{
+			Title: "int64 from float64",
+			Input: Input{"/port", getInt64},
+			Expect: Expect{
+				Value: int64(8080),
+				Err:   nil,
+			},
+		},
+		{
+			Title: "int64 with fraction",
+			Input: Input{"/ratio", getInt64},
+			Expect: Expect{
+				Value: int64(0),
+				Err:   NewTypeMismatchError(0.5, int64Type, pathFromKeys([]string{"ratio"})),
+			},
+		},
+		{
+			Title: "float64",
+			Input: Input{"/ratio", getFloat64},
+			Expect: Expect{
+				Value: 0.5,
+				Err:   nil,
+			},
+		},
+		{
+			Title: "bool",
+			Input: Input{"/enabled", getBool},
+			Expect: Expect{
+				Value: true,
+				Err:   nil,
+			},
+		},
+		{
+			Title: "duration from string",
+			Input: Input{"/timeout", getDuration},
+			Expect: Expect{
+				Value: 90 * time.Second,
+				Err:   nil,
+			},
+		},
+		{
+			Title: "duration from number",
+			Input: Input{"/interval", getDuration},
+			Expect: Expect{
+				Value: 1000 * time.Nanosecond,
+				Err:   nil,
+			},
+		},
+		{
+			Title: "invalid duration",
+			Input: Input{"/name", getDuration},
+			Expect: Expect{
+				Value: time.Duration(0),
+				Err:   NewTypeMismatchError("hello", durationType, pathFromKeys([]string{"name"})),
+			},
+		},
+		{
+			Title: "time",
+			Input: Input{"/created", getTime},
+			Expect: Expect{
+				Value: time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC),
+				Err:   nil,
+			},
+		},
+		{
+			Title: "string slice",
+			Input: Input{"/tags", getStringSlice},
+			Expect: Expect{
+				Value: []string{"a", "b"},
+				Err:   nil,
+			},
+		},
+		{
+			Title: "mixed slice",
+			Input: Input{"/mixed", getStringSlice},
+			Expect: Expect{
+				Value: []string(nil),
+				Err:   NewTypeMismatchError([]interface{}{"a", float64(1)}, stringSliceType, pathFromKeys([]string{"mixed"})),
+			},
+		},
+		{
+			Title: "string map",
+			Input: Input{"/labels", getStringMap},
+			Expect: Expect{
+				Value: map[string]interface{}{"a": "b"},
+				Err:   nil,
+			},
+		},
+		{
+			Title: "null",
+			Input: Input{"/null", getString},
+			Expect: Expect{
+				Value: "",
+				Err:   NewTypeMismatchError(nil, stringType, pathFromKeys([]string{"null"})),
+			},
+		},
+		{
+			Title: "type mismatch",
+			Input: Input{"/port", getString},
+			Expect: Expect{
+				Value: "",
+				Err:   NewTypeMismatchError(float64(8080), stringType, pathFromKeys([]string{"port"})),
+			},
+		},
+		{
+			Title: "no such key",
+			Input: Input{"/missing", getBool},
+			Expect: Expect{
+				Value: false,
+				Err:   NewNoSuchPathError("no such key", "missing"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+
+			value, err := testCase.Input.Getter(acc, path)
+			assert.Equal(testCase.Expect.Err, err)
+			assert.Equal(testCase.Expect.Value, value)
+		})
+	}
+}
+
+func TestGetInt64_Overflow(t *testing.T) {
+	assert := assert.New(t)
+
+	acc := mustAccessor(t, map[string]interface{}{"max": uint64(math.MaxUint64), "neg": -1})
+
+	n, err := GetInt64(acc, newPath("max"))
+	assert.Equal(NewTypeMismatchError(uint64(math.MaxUint64), int64Type, newPath("max")), err)
+	assert.Equal(int64(0), n)
+
+	n, err = GetInt64(acc, newPath("neg"))
+	assert.Nil(err)
+	assert.Equal(int64(-1), n)
+}
+
+func TestGetters_YAMLAndTOML(t *testing.T) {
+	assert := assert.New(t)
+
+	yml, err := FromYAML(strings.NewReader("port: 8080\nratio: 1\n"))
+	assert.Nil(err)
+	port, err := GetInt64(yml, pathFromKeys([]string{"port"}))
+	assert.Nil(err)
+	assert.Equal(int64(8080), port)
+	ratio, err := GetFloat64(yml, pathFromKeys([]string{"ratio"}))
+	assert.Nil(err)
+	assert.Equal(float64(1), ratio)
+
+	tml, err := FromTOML(strings.NewReader("port = 8080\ncreated = 2018-01-02T03:04:05Z\n"))
+	assert.Nil(err)
+	port, err = GetInt64(tml, pathFromKeys([]string{"port"}))
+	assert.Nil(err)
+	assert.Equal(int64(8080), port)
+	created, err := GetTime(tml, pathFromKeys([]string{"created"}))
+	assert.Nil(err)
+	assert.True(created.Equal(time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+	_, err = GetTime(tml, pathFromKeys([]string{"port"}))
+	assert.Equal(NewTypeMismatchError(int64(8080), reflect.TypeOf(time.Time{}), pathFromKeys([]string{"port"})), err)
+}
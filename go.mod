@@ -0,0 +1,15 @@
+module github.com/morikuni/accessor
+
+go 1.18
+
+require (
+	github.com/BurntSushi/toml v0.3.0
+	github.com/stretchr/testify v1.8.4
+	gopkg.in/yaml.v2 v2.4.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
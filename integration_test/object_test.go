@@ -149,3 +149,49 @@ name = "world2"
 	assert.Nil(err)
 	assert.Equal(exceptObject, result)
 }
+
+func TestObject_YAMLNonStringKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	input := `name: me
+ports:
+  80: http
+  443: https
+`
+	var inputObject interface{}
+	err := yaml.Unmarshal([]byte(input), &inputObject)
+	assert.Nil(err)
+
+	_, err = accessor.NewAccessor(inputObject)
+	assert.NotNil(err)
+
+	acc, err := accessor.NewAccessor(inputObject, accessor.StringifyKeys())
+	assert.Nil(err)
+
+	path, err := accessor.ParsePath("/ports/443")
+	assert.Nil(err)
+	err = acc.Set(path, "tls")
+	assert.Nil(err)
+
+	assert.Equal(map[interface{}]interface{}{
+		"name": "me",
+		"ports": map[interface{}]interface{}{
+			80:  "http",
+			443: "tls",
+		},
+	}, acc.Unwrap())
+
+	bs, err := yaml.Marshal(acc.Unwrap())
+	assert.Nil(err)
+
+	var result interface{}
+	err = yaml.Unmarshal(bs, &result)
+	assert.Nil(err)
+	assert.Equal(map[interface{}]interface{}{
+		"name": "me",
+		"ports": map[interface{}]interface{}{
+			80:  "http",
+			443: "tls",
+		},
+	}, result)
+}
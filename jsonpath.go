@@ -0,0 +1,959 @@
+package accessor
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// JSONPath is a RFC 9535 JSONPath expression.
+type JSONPath struct {
+	segments []jsonPathSegment
+}
+
+// ParseJSONPath parses a RFC 9535 JSONPath expression like
+// "$.friends[?@.age > 18].name".
+// InvalidPathError is returned when the expression is malformed.
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	p := &jsonPathParser{s: expr}
+	if !p.consume("$") {
+		return nil, p.errorf("expression must start with $")
+	}
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return &JSONPath{segments}, nil
+}
+
+// Evaluate finds all objects selected by the expression.
+// Each Match has the normalized Path, which can be passed to Accessor.Set.
+func (p *JSONPath) Evaluate(acc Accessor) ([]Match, error) {
+	nodes, err := evalJSONPathSegments(p.segments, acc, acc)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Match
+	for _, n := range nodes {
+		matches = append(matches, Match{pathFromKeys(n.keys), n.acc})
+	}
+	return matches, nil
+}
+
+// QueryJSONPath parses the JSONPath expression and evaluates it.
+func QueryJSONPath(acc Accessor, expr string) ([]Match, error) {
+	p, err := ParseJSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	return p.Evaluate(acc)
+}
+
+type jsonPathNode struct {
+	keys []string
+	acc  Accessor
+}
+
+func evalJSONPathSegments(segments []jsonPathSegment, root, start Accessor) ([]jsonPathNode, error) {
+	nodes := []jsonPathNode{{nil, start}}
+	for _, seg := range segments {
+		var next []jsonPathNode
+		for _, n := range nodes {
+			var err error
+			if seg.descendant {
+				err = descendJSONPath(n, func(d jsonPathNode) error {
+					return seg.apply(root, d, &next)
+				})
+			} else {
+				err = seg.apply(root, n, &next)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		nodes = next
+	}
+	return nodes, nil
+}
+
+func descendJSONPath(n jsonPathNode, f func(jsonPathNode) error) error {
+	err := f(n)
+	if err != nil {
+		return err
+	}
+	return eachChild(n.acc, func(key string, child Accessor) error {
+		return descendJSONPath(jsonPathNode{appendKey(n.keys, key), child}, f)
+	})
+}
+
+type jsonPathSegment struct {
+	descendant bool
+	selectors  []jsonPathSelector
+}
+
+func (s jsonPathSegment) apply(root Accessor, n jsonPathNode, out *[]jsonPathNode) error {
+	for _, sel := range s.selectors {
+		err := sel.apply(root, n, out)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonPathSelector interface {
+	apply(root Accessor, n jsonPathNode, out *[]jsonPathNode) error
+}
+
+type jsonPathName string
+
+func (s jsonPathName) apply(root Accessor, n jsonPathNode, out *[]jsonPathNode) error {
+	if isSlice(n.acc) {
+		return nil
+	}
+
+	child, err := n.acc.Get(thePhantomPath.PushKey(string(s)))
+	if err != nil {
+		if _, ok := err.(*NoSuchPathError); ok {
+			return nil
+		}
+		return err
+	}
+	*out = append(*out, jsonPathNode{appendKey(n.keys, string(s)), child})
+	return nil
+}
+
+type jsonPathWildcard struct{}
+
+func (s jsonPathWildcard) apply(root Accessor, n jsonPathNode, out *[]jsonPathNode) error {
+	return eachChild(n.acc, func(key string, child Accessor) error {
+		*out = append(*out, jsonPathNode{appendKey(n.keys, key), child})
+		return nil
+	})
+}
+
+type jsonPathIndex int
+
+func (s jsonPathIndex) apply(root Accessor, n jsonPathNode, out *[]jsonPathNode) error {
+	length, ok, err := sliceLength(n.acc)
+	if err != nil || !ok {
+		return err
+	}
+
+	i := int(s)
+	if i < 0 {
+		i += length
+	}
+	if i < 0 || i >= length {
+		return nil
+	}
+	return appendElement(n, i, out)
+}
+
+// sliceLength returns the length of the object if it is a slice.
+func sliceLength(acc Accessor) (int, bool, error) {
+	if !isSlice(acc) {
+		return 0, false, nil
+	}
+	_, keys, err := listOf(acc)
+	if err != nil {
+		return 0, false, err
+	}
+	return len(keys), true, nil
+}
+
+// appendElement appends the element of the index in the slice to out.
+func appendElement(n jsonPathNode, i int, out *[]jsonPathNode) error {
+	key := strconv.Itoa(i)
+	child, err := n.acc.Get(thePhantomPath.PushKey(key))
+	if err != nil {
+		return err
+	}
+	*out = append(*out, jsonPathNode{appendKey(n.keys, key), child})
+	return nil
+}
+
+type jsonPathSlice struct {
+	start, end, step *int
+}
+
+func (s jsonPathSlice) apply(root Accessor, n jsonPathNode, out *[]jsonPathNode) error {
+	length, ok, err := sliceLength(n.acc)
+	if err != nil || !ok {
+		return err
+	}
+
+	step := 1
+	if s.step != nil {
+		step = *s.step
+	}
+	if step == 0 {
+		return nil
+	}
+
+	normalize := func(i *int, def int) int {
+		if i == nil {
+			return def
+		}
+		if *i < 0 {
+			return length + *i
+		}
+		return *i
+	}
+	clamp := func(i, min, max int) int {
+		if i < min {
+			return min
+		}
+		if i > max {
+			return max
+		}
+		return i
+	}
+
+	if step > 0 {
+		lower := clamp(normalize(s.start, 0), 0, length)
+		upper := clamp(normalize(s.end, length), 0, length)
+		for i := lower; i < upper; i += step {
+			if err := appendElement(n, i, out); err != nil {
+				return err
+			}
+		}
+	} else {
+		upper := clamp(normalize(s.start, length-1), -1, length-1)
+		lower := clamp(normalize(s.end, -length-1), -1, length-1)
+		for i := upper; lower < i; i += step {
+			if err := appendElement(n, i, out); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type jsonPathFilter struct {
+	expr jsonPathLogical
+}
+
+func (s jsonPathFilter) apply(root Accessor, n jsonPathNode, out *[]jsonPathNode) error {
+	return eachChild(n.acc, func(key string, child Accessor) error {
+		ok, err := s.expr.test(root, child)
+		if err != nil {
+			return err
+		}
+		if ok {
+			*out = append(*out, jsonPathNode{appendKey(n.keys, key), child})
+		}
+		return nil
+	})
+}
+
+// jsonPathLogical is a logical expression in a filter.
+type jsonPathLogical interface {
+	test(root, current Accessor) (bool, error)
+}
+
+type jsonPathOr struct {
+	left, right jsonPathLogical
+}
+
+func (e jsonPathOr) test(root, current Accessor) (bool, error) {
+	ok, err := e.left.test(root, current)
+	if err != nil || ok {
+		return ok, err
+	}
+	return e.right.test(root, current)
+}
+
+type jsonPathAnd struct {
+	left, right jsonPathLogical
+}
+
+func (e jsonPathAnd) test(root, current Accessor) (bool, error) {
+	ok, err := e.left.test(root, current)
+	if err != nil || !ok {
+		return ok, err
+	}
+	return e.right.test(root, current)
+}
+
+type jsonPathNot struct {
+	expr jsonPathLogical
+}
+
+func (e jsonPathNot) test(root, current Accessor) (bool, error) {
+	ok, err := e.expr.test(root, current)
+	return !ok, err
+}
+
+type jsonPathExistence struct {
+	query *jsonPathQuery
+}
+
+func (e jsonPathExistence) test(root, current Accessor) (bool, error) {
+	nodes, err := e.query.nodes(root, current)
+	return len(nodes) > 0, err
+}
+
+type jsonPathComparison struct {
+	op          string
+	left, right jsonPathOperand
+}
+
+func (e jsonPathComparison) test(root, current Accessor) (bool, error) {
+	a, err := e.left.value(root, current)
+	if err != nil {
+		return false, err
+	}
+	b, err := e.right.value(root, current)
+	if err != nil {
+		return false, err
+	}
+
+	switch e.op {
+	case "==":
+		return jsonPathEqual(a, b), nil
+	case "!=":
+		return !jsonPathEqual(a, b), nil
+	case "<":
+		return jsonPathLess(a, b), nil
+	case "<=":
+		return jsonPathLess(a, b) || jsonPathEqual(a, b), nil
+	case ">":
+		return jsonPathLess(b, a), nil
+	case ">=":
+		return jsonPathLess(b, a) || jsonPathEqual(a, b), nil
+	default:
+		return false, NewInvalidPathError(fmt.Sprintf("unknown operator %q", e.op))
+	}
+}
+
+// jsonPathEqual compares values, where nil means nothing.
+func jsonPathEqual(a, b Accessor) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return Equal(a, b, IgnoreNumericTypes())
+}
+
+func jsonPathLess(a, b Accessor) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	av, bv := a.Unwrap(), b.Unwrap()
+	an, aok := toNumber(av)
+	bn, bok := toNumber(bv)
+	if aok && bok {
+		return an.Cmp(bn) < 0
+	}
+
+	as, aok := av.(string)
+	bs, bok := bv.(string)
+	if aok && bok {
+		return as < bs
+	}
+	return false
+}
+
+// jsonPathOperand is a value in a comparison or a function argument.
+// The value is nil when there is nothing.
+type jsonPathOperand interface {
+	value(root, current Accessor) (Accessor, error)
+}
+
+type jsonPathLiteral struct {
+	v interface{}
+}
+
+func (o jsonPathLiteral) value(root, current Accessor) (Accessor, error) {
+	return &ValueAccessor{o.v}, nil
+}
+
+type jsonPathQuery struct {
+	relative bool
+	segments []jsonPathSegment
+}
+
+func (o *jsonPathQuery) nodes(root, current Accessor) ([]jsonPathNode, error) {
+	start := root
+	if o.relative {
+		start = current
+	}
+	return evalJSONPathSegments(o.segments, root, start)
+}
+
+func (o *jsonPathQuery) value(root, current Accessor) (Accessor, error) {
+	nodes, err := o.nodes(root, current)
+	if err != nil || len(nodes) != 1 {
+		return nil, err
+	}
+	return nodes[0].acc, nil
+}
+
+type jsonPathFunction struct {
+	name string
+	args []jsonPathOperand
+}
+
+// jsonPathFunctions holds the number of arguments and
+// whether the result is logical for each function.
+var jsonPathFunctions = map[string]struct {
+	arity   int
+	logical bool
+}{
+	"length": {1, false},
+	"count":  {1, false},
+	"value":  {1, false},
+	"match":  {2, true},
+	"search": {2, true},
+}
+
+func (o *jsonPathFunction) value(root, current Accessor) (Accessor, error) {
+	switch o.name {
+	case "length":
+		v, err := o.args[0].value(root, current)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		s, keys, err := listOf(v)
+		if err != nil {
+			return nil, err
+		}
+		if s != shapeValue {
+			return &ValueAccessor{len(keys)}, nil
+		}
+		if s, ok := v.Unwrap().(string); ok {
+			return &ValueAccessor{utf8.RuneCountInString(s)}, nil
+		}
+		return nil, nil
+	case "count":
+		nodes, err := o.args[0].(*jsonPathQuery).nodes(root, current)
+		if err != nil {
+			return nil, err
+		}
+		return &ValueAccessor{len(nodes)}, nil
+	case "value":
+		return o.args[0].value(root, current)
+	default:
+		ok, err := o.test(root, current)
+		if err != nil {
+			return nil, err
+		}
+		return &ValueAccessor{ok}, nil
+	}
+}
+
+func (o *jsonPathFunction) test(root, current Accessor) (bool, error) {
+	var strs [2]string
+	for i, arg := range o.args {
+		v, err := arg.value(root, current)
+		if err != nil || v == nil {
+			return false, err
+		}
+		s, ok := v.Unwrap().(string)
+		if !ok {
+			return false, nil
+		}
+		strs[i] = s
+	}
+
+	pattern := strs[1]
+	if o.name == "match" {
+		pattern = "^(?:" + pattern + ")$"
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, nil
+	}
+	return re.MatchString(strs[0]), nil
+}
+
+type jsonPathParser struct {
+	s   string
+	pos int
+}
+
+func (p *jsonPathParser) errorf(format string, args ...interface{}) error {
+	return NewInvalidPathError(fmt.Sprintf("%s at %d", fmt.Sprintf(format, args...), p.pos))
+}
+
+func (p *jsonPathParser) peek() byte {
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *jsonPathParser) consume(s string) bool {
+	if strings.HasPrefix(p.s[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) expect(s string) error {
+	if !p.consume(s) {
+		return p.errorf("%q is expected", s)
+	}
+	return nil
+}
+
+func (p *jsonPathParser) skipSpaces() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\n\r", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *jsonPathParser) parseSegments() ([]jsonPathSegment, error) {
+	var segments []jsonPathSegment
+	for {
+		start := p.pos
+		p.skipSpaces()
+
+		var seg jsonPathSegment
+		var err error
+		switch {
+		case p.consume(".."):
+			if p.peek() == '[' {
+				seg, err = p.parseBracket()
+			} else {
+				seg, err = p.parseDotSelector()
+			}
+			seg.descendant = true
+		case p.consume("."):
+			seg, err = p.parseDotSelector()
+		case p.peek() == '[':
+			seg, err = p.parseBracket()
+		default:
+			p.pos = start
+			return segments, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, seg)
+	}
+}
+
+func (p *jsonPathParser) parseDotSelector() (jsonPathSegment, error) {
+	if p.consume("*") {
+		return jsonPathSegment{selectors: []jsonPathSelector{jsonPathWildcard{}}}, nil
+	}
+
+	name := p.parseName(false)
+	if name == "" {
+		return jsonPathSegment{}, p.errorf("name is expected")
+	}
+	return jsonPathSegment{selectors: []jsonPathSelector{jsonPathName(name)}}, nil
+}
+
+// parseName parses a member name shorthand, or a function name when function is true.
+func (p *jsonPathParser) parseName(function bool) string {
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		isLower := 'a' <= c && c <= 'z'
+		isDigit := '0' <= c && c <= '9'
+		isAlpha := isLower || ('A' <= c && c <= 'Z') || c == '_' || c >= 0x80
+		if function {
+			isAlpha = isLower
+			isDigit = isDigit || c == '_'
+		}
+		if !isAlpha && !(isDigit && p.pos > start) {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *jsonPathParser) parseBracket() (jsonPathSegment, error) {
+	err := p.expect("[")
+	if err != nil {
+		return jsonPathSegment{}, err
+	}
+
+	var seg jsonPathSegment
+	for {
+		p.skipSpaces()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return jsonPathSegment{}, err
+		}
+		seg.selectors = append(seg.selectors, sel)
+
+		p.skipSpaces()
+		if p.consume(",") {
+			continue
+		}
+		err = p.expect("]")
+		if err != nil {
+			return jsonPathSegment{}, err
+		}
+		return seg, nil
+	}
+}
+
+func (p *jsonPathParser) parseSelector() (jsonPathSelector, error) {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathName(s), nil
+	case c == '*':
+		p.pos++
+		return jsonPathWildcard{}, nil
+	case c == '?':
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathFilter{expr}, nil
+	}
+
+	start, err := p.parseInt()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if !p.consume(":") {
+		if start == nil {
+			return nil, p.errorf("selector is expected")
+		}
+		return jsonPathIndex(*start), nil
+	}
+
+	p.skipSpaces()
+	end, err := p.parseInt()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	var step *int
+	if p.consume(":") {
+		p.skipSpaces()
+		step, err = p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return jsonPathSlice{start, end, step}, nil
+}
+
+// parseInt parses an integer if exists.
+func (p *jsonPathParser) parseInt() (*int, error) {
+	start := p.pos
+	p.consume("-")
+	for '0' <= p.peek() && p.peek() <= '9' {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, nil
+	}
+
+	i, err := strconv.Atoi(p.s[start:p.pos])
+	if err != nil {
+		return nil, p.errorf("invalid integer %q", p.s[start:p.pos])
+	}
+	return &i, nil
+}
+
+func (p *jsonPathParser) parseString() (string, error) {
+	quote := p.s[p.pos]
+	p.pos++
+
+	var buf []rune
+	for {
+		if p.pos >= len(p.s) {
+			return "", p.errorf("string is not terminated")
+		}
+		r, size := utf8.DecodeRuneInString(p.s[p.pos:])
+		p.pos += size
+		switch {
+		case r == rune(quote):
+			return string(buf), nil
+		case r != '\\':
+			buf = append(buf, r)
+			continue
+		}
+
+		c := p.peek()
+		p.pos++
+		switch c {
+		case 'b':
+			buf = append(buf, '\b')
+		case 'f':
+			buf = append(buf, '\f')
+		case 'n':
+			buf = append(buf, '\n')
+		case 'r':
+			buf = append(buf, '\r')
+		case 't':
+			buf = append(buf, '\t')
+		case '/', '\\', '\'', '"':
+			buf = append(buf, rune(c))
+		case 'u':
+			r, err := p.parseUnicode()
+			if err != nil {
+				return "", err
+			}
+			if utf16.IsSurrogate(r) && p.consume(`\u`) {
+				r2, err := p.parseUnicode()
+				if err != nil {
+					return "", err
+				}
+				r = utf16.DecodeRune(r, r2)
+			}
+			buf = append(buf, r)
+		default:
+			return "", p.errorf("invalid escape sequence")
+		}
+	}
+}
+
+func (p *jsonPathParser) parseUnicode() (rune, error) {
+	if p.pos+4 > len(p.s) {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	i, err := strconv.ParseUint(p.s[p.pos:p.pos+4], 16, 16)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	p.pos += 4
+	return rune(i), nil
+}
+
+func (p *jsonPathParser) parseOr() (jsonPathLogical, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.consume("||") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = jsonPathOr{left, right}
+	}
+}
+
+func (p *jsonPathParser) parseAnd() (jsonPathLogical, error) {
+	left, err := p.parseBasic()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.consume("&&") {
+			return left, nil
+		}
+		right, err := p.parseBasic()
+		if err != nil {
+			return nil, err
+		}
+		left = jsonPathAnd{left, right}
+	}
+}
+
+func (p *jsonPathParser) parseBasic() (jsonPathLogical, error) {
+	p.skipSpaces()
+	if p.consume("!") {
+		p.skipSpaces()
+		var expr jsonPathLogical
+		var err error
+		if p.peek() == '(' {
+			expr, err = p.parseParen()
+		} else {
+			var operand jsonPathOperand
+			operand, err = p.parseOperand()
+			if err == nil {
+				expr, err = p.toTest(operand)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathNot{expr}, nil
+	}
+	if p.peek() == '(' {
+		return p.parseParen()
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if !p.consume(op) {
+			continue
+		}
+		p.skipSpaces()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		for _, operand := range []jsonPathOperand{left, right} {
+			if f, ok := operand.(*jsonPathFunction); ok && jsonPathFunctions[f.name].logical {
+				return nil, p.errorf("function %s cannot be compared", f.name)
+			}
+		}
+		return jsonPathComparison{op, left, right}, nil
+	}
+	return p.toTest(left)
+}
+
+func (p *jsonPathParser) parseParen() (jsonPathLogical, error) {
+	err := p.expect("(")
+	if err != nil {
+		return nil, err
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	err = p.expect(")")
+	if err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+// toTest converts the operand into a test expression.
+func (p *jsonPathParser) toTest(operand jsonPathOperand) (jsonPathLogical, error) {
+	switch o := operand.(type) {
+	case *jsonPathQuery:
+		return jsonPathExistence{o}, nil
+	case *jsonPathFunction:
+		if !jsonPathFunctions[o.name].logical {
+			return nil, p.errorf("function %s cannot be a test", o.name)
+		}
+		return o, nil
+	default:
+		return nil, p.errorf("literal cannot be a test")
+	}
+}
+
+func (p *jsonPathParser) parseOperand() (jsonPathOperand, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		p.pos++
+		segments, err := p.parseSegments()
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathQuery{c == '@', segments}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathLiteral{s}, nil
+	case c == '-' || ('0' <= c && c <= '9'):
+		return p.parseNumber()
+	}
+
+	switch {
+	case p.consume("true"):
+		return jsonPathLiteral{true}, nil
+	case p.consume("false"):
+		return jsonPathLiteral{false}, nil
+	case p.consume("null"):
+		return jsonPathLiteral{nil}, nil
+	}
+
+	name := p.parseName(true)
+	if name == "" {
+		return nil, p.errorf("operand is expected")
+	}
+	return p.parseFunction(name)
+}
+
+// parseNumber parses a number literal into json.Number,
+// which equals the number decoded from the same text by encoding/json.
+func (p *jsonPathParser) parseNumber() (jsonPathOperand, error) {
+	start := p.pos
+	p.consume("-")
+	digits := func() bool {
+		s := p.pos
+		for '0' <= p.peek() && p.peek() <= '9' {
+			p.pos++
+		}
+		return p.pos > s
+	}
+	if !digits() {
+		return nil, p.errorf("invalid number")
+	}
+	if p.consume(".") && !digits() {
+		return nil, p.errorf("invalid number")
+	}
+	if p.consume("e") || p.consume("E") {
+		if !p.consume("+") {
+			p.consume("-")
+		}
+		if !digits() {
+			return nil, p.errorf("invalid number")
+		}
+	}
+	return jsonPathLiteral{json.Number(p.s[start:p.pos])}, nil
+}
+
+func (p *jsonPathParser) parseFunction(name string) (jsonPathOperand, error) {
+	def, ok := jsonPathFunctions[name]
+	if !ok {
+		return nil, p.errorf("unknown function %s", name)
+	}
+	err := p.expect("(")
+	if err != nil {
+		return nil, err
+	}
+
+	f := &jsonPathFunction{name: name}
+	for {
+		p.skipSpaces()
+		arg, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		f.args = append(f.args, arg)
+
+		p.skipSpaces()
+		if p.consume(",") {
+			continue
+		}
+		err = p.expect(")")
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	if len(f.args) != def.arity {
+		return nil, p.errorf("function %s requires %d arguments", name, def.arity)
+	}
+	if name == "count" || name == "value" {
+		if _, ok := f.args[0].(*jsonPathQuery); !ok {
+			return nil, p.errorf("function %s requires a query", name)
+		}
+	}
+	return f, nil
+}
@@ -0,0 +1,284 @@
+package accessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryJSONPath(t *testing.T) {
+	type Input struct {
+		Expr string
+	}
+	type Expect struct {
+		Pointers []string
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	acc := mustJSONAccessor(t, `{
+		"name": "me",
+		"friends": [
+			{"name": "hello", "age": 17, "score": 0.1, "tags": ["a"]},
+			{"name": "world", "age": 20, "score": 0.2},
+			{"name": "foo bar", "age": 30, "score": 0.3, "tags": ["a", "b"]}
+		],
+		"a/b": 1
+	}`)
+
+	table := []Test{
+		{
+			Title: "root",
+			Input: Input{
+				Expr: "$",
+			},
+			Expect: Expect{
+				Pointers: []string{""},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "dot notation",
+			Input: Input{
+				Expr: "$.friends[1].name",
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/1/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "bracket notation",
+			Input: Input{
+				Expr: `$['a/b']`,
+			},
+			Expect: Expect{
+				Pointers: []string{"/a~1b"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "negative index",
+			Input: Input{
+				Expr: "$.friends[-1].name",
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/2/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "wildcard",
+			Input: Input{
+				Expr: "$.friends[*].name",
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/0/name", "/friends/1/name", "/friends/2/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "slice",
+			Input: Input{
+				Expr: "$.friends[0:2].name",
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/0/name", "/friends/1/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "reverse slice",
+			Input: Input{
+				Expr: "$.friends[::-2]",
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/2", "/friends/0"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "union",
+			Input: Input{
+				Expr: `$.friends[0]['name', "age"]`,
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/0/name", "/friends/0/age"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "descendant",
+			Input: Input{
+				Expr: "$..name",
+			},
+			Expect: Expect{
+				Pointers: []string{"/name", "/friends/0/name", "/friends/1/name", "/friends/2/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "filter",
+			Input: Input{
+				Expr: "$.friends[?(@.age > 18)].name",
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/1/name", "/friends/2/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "filter fraction equal",
+			Input: Input{
+				Expr: "$.friends[?@.score == 0.1].name",
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/0/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "filter fraction compare",
+			Input: Input{
+				Expr: "$.friends[?@.score <= 0.2 || @.score > 2.5e-1].name",
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/0/name", "/friends/1/name", "/friends/2/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "filter fraction less",
+			Input: Input{
+				Expr: "$.friends[?@.score < 0.2].name",
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/0/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "filter logical",
+			Input: Input{
+				Expr: `$.friends[?@.age >= 20 && !(@.name == 'world') || @.name == "hello"]`,
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/0", "/friends/2"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "filter existence",
+			Input: Input{
+				Expr: "$.friends[?@.tags].name",
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/0/name", "/friends/2/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "filter root",
+			Input: Input{
+				Expr: "$.friends[?@.name == $.friends[1].name]",
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/1"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "filter functions",
+			Input: Input{
+				Expr: `$.friends[?length(@.tags) == 2 || count(@.*) == 3 || match(@.name, 'h.*')].name`,
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/0/name", "/friends/1/name", "/friends/2/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "filter search",
+			Input: Input{
+				Expr: `$.friends[?search(@.name, ' ')].name`,
+			},
+			Expect: Expect{
+				Pointers: []string{"/friends/2/name"},
+				Err:      nil,
+			},
+		},
+		{
+			Title: "no match",
+			Input: Input{
+				Expr: "$.friends[5]",
+			},
+			Expect: Expect{
+				Pointers: nil,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "no root",
+			Input: Input{
+				Expr: "friends",
+			},
+			Expect: Expect{
+				Pointers: nil,
+				Err:      NewInvalidPathError("expression must start with $ at 0"),
+			},
+		},
+		{
+			Title: "not closed",
+			Input: Input{
+				Expr: "$.friends[0",
+			},
+			Expect: Expect{
+				Pointers: nil,
+				Err:      NewInvalidPathError(`"]" is expected at 11`),
+			},
+		},
+		{
+			Title: "literal test",
+			Input: Input{
+				Expr: "$.friends[?1]",
+			},
+			Expect: Expect{
+				Pointers: nil,
+				Err:      NewInvalidPathError("literal cannot be a test at 12"),
+			},
+		},
+		{
+			Title: "unknown function",
+			Input: Input{
+				Expr: "$.friends[?foo(@)]",
+			},
+			Expect: Expect{
+				Pointers: nil,
+				Err:      NewInvalidPathError("unknown function foo at 14"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			matches, err := QueryJSONPath(acc, testCase.Input.Expr)
+
+			var pointers []string
+			for _, m := range matches {
+				pointers = append(pointers, m.Path.JSONPointer())
+
+				found, err := acc.Get(m.Path)
+				assert.Nil(err)
+				assert.Equal(found, m.Accessor)
+			}
+			assert.Equal(testCase.Expect.Pointers, pointers)
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package accessor
+
+// KeyedMapAccessor is the Accessor for a map whose keys are not strings,
+// such as map[interface{}]interface{} decoded by gopkg.in/yaml.v2.
+// The keys are stringified to be addressed by a Path,
+// and Unwrap restores the original keys recorded in Keys.
+type KeyedMapAccessor struct {
+	Map  MapAccessor
+	Keys map[string]interface{}
+}
+
+// Get implements Accessor.
+func (a KeyedMapAccessor) Get(path Path) (Accessor, error) {
+	if path == thePhantomPath {
+		return a, nil
+	}
+	return a.Map.Get(path)
+}
+
+// Set implements Accessor.
+func (a KeyedMapAccessor) Set(path Path, value interface{}) error {
+	return a.Map.Set(path, value)
+}
+
+// SetCreate is same as MapAccessor.SetCreate.
+func (a KeyedMapAccessor) SetCreate(path Path, value interface{}) error {
+	return a.Map.SetCreate(path, value)
+}
+
+func (a KeyedMapAccessor) setCreate(path Path, value interface{}) (Accessor, error) {
+	_, err := a.Map.setCreate(path, value)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Insert is same as MapAccessor.Insert.
+func (a KeyedMapAccessor) Insert(path Path, value interface{}) error {
+	return a.Map.Insert(path, value)
+}
+
+func (a KeyedMapAccessor) insert(path Path, value interface{}) (Accessor, error) {
+	_, err := a.Map.insert(path, value)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Delete implements Accessor.
+func (a KeyedMapAccessor) Delete(path Path) error {
+	err := a.Map.Delete(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := path.SubPath(); !ok {
+		delete(a.Keys, path.Key())
+	}
+	return nil
+}
+
+// Unwrap implements Accessor.
+// It returns map[interface{}]interface{} with the original keys.
+func (a KeyedMapAccessor) Unwrap() interface{} {
+	result := map[interface{}]interface{}{}
+	for k, v := range a.Map {
+		key, ok := a.Keys[k]
+		if !ok {
+			key = k
+		}
+		result[key] = v.Unwrap()
+	}
+	return result
+}
+
+// Foreach implements Accessor.
+func (a KeyedMapAccessor) Foreach(f func(path Path, value interface{}) error) error {
+	return a.Map.Foreach(f)
+}
+
+func (a KeyedMapAccessor) base() Accessor {
+	return a.Map
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (a KeyedMapAccessor) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// MarshalYAML implements gopkg.in/yaml.v2.Marshaler.
+func (a KeyedMapAccessor) MarshalYAML() (interface{}, error) {
+	return marshalYAML(a)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the JSON encoding.
+func (a KeyedMapAccessor) MarshalText() ([]byte, error) {
+	return marshalJSON(a)
+}
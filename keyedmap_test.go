@@ -0,0 +1,140 @@
+package accessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccessor_StringifyKeys(t *testing.T) {
+	type Input struct {
+		Value   interface{}
+		Options []Option
+	}
+	type Expect struct {
+		Accessor Accessor
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "without option",
+			Input: Input{
+				Value: map[interface{}]interface{}{
+					1: "a",
+				},
+				Options: nil,
+			},
+			Expect: Expect{
+				Accessor: nil,
+				Err:      NewInvalidKeyError(1),
+			},
+		},
+		{
+			Title: "stringify",
+			Input: Input{
+				Value: map[interface{}]interface{}{
+					1:    "a",
+					true: map[interface{}]interface{}{"b": 2.5},
+					"c":  []interface{}{map[interface{}]interface{}{1.5: nil}},
+				},
+				Options: []Option{StringifyKeys()},
+			},
+			Expect: Expect{
+				Accessor: KeyedMapAccessor{
+					Map: MapAccessor(map[string]Accessor{
+						"1": &ValueAccessor{"a"},
+						"true": KeyedMapAccessor{
+							Map: MapAccessor(map[string]Accessor{
+								"b": &ValueAccessor{2.5},
+							}),
+							Keys: map[string]interface{}{},
+						},
+						"c": SliceAccessor([]Accessor{
+							KeyedMapAccessor{
+								Map: MapAccessor(map[string]Accessor{
+									"1.5": &ValueAccessor{nil},
+								}),
+								Keys: map[string]interface{}{"1.5": 1.5},
+							},
+						}),
+					}),
+					Keys: map[string]interface{}{"1": 1, "true": true},
+				},
+				Err: nil,
+			},
+		},
+		{
+			Title: "not a scalar",
+			Input: Input{
+				Value: map[interface{}]interface{}{
+					[2]int{1, 2}: "a",
+				},
+				Options: []Option{StringifyKeys()},
+			},
+			Expect: Expect{
+				Accessor: nil,
+				Err:      NewInvalidKeyError([2]int{1, 2}),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			acc, err := NewAccessor(testCase.Input.Value, testCase.Input.Options...)
+
+			assert.Equal(testCase.Expect.Accessor, acc)
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+
+	t.Run("duplicated key", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := NewAccessor(map[interface{}]interface{}{
+			1:   "a",
+			"1": "b",
+		}, StringifyKeys())
+
+		assert.IsType(&InvalidKeyError{}, err)
+	})
+}
+
+func TestKeyedMapAccessor(t *testing.T) {
+	assert := assert.New(t)
+
+	acc, err := NewAccessor(map[interface{}]interface{}{
+		1:   "a",
+		2:   "b",
+		"c": 3,
+	}, StringifyKeys())
+	assert.Nil(err)
+
+	v, err := acc.Get(newPath("1"))
+	assert.Nil(err)
+	assert.Equal(&ValueAccessor{"a"}, v)
+
+	assert.Nil(acc.Set(newPath("1"), "x"))
+	assert.Nil(acc.Delete(newPath("2")))
+	assert.Nil(acc.(KeyedMapAccessor).SetCreate(newPath("d", "e"), 4))
+
+	assert.Equal(map[interface{}]interface{}{
+		1:   "x",
+		"c": 3,
+		"d": map[string]interface{}{"e": 4},
+	}, acc.Unwrap())
+
+	assert.Equal(acc, Clone(acc))
+	assert.True(Equal(acc, MapAccessor(map[string]Accessor{
+		"1": &ValueAccessor{"x"},
+		"c": &ValueAccessor{3},
+		"d": MapAccessor(map[string]Accessor{"e": &ValueAccessor{4}}),
+	})))
+}
@@ -0,0 +1,165 @@
+package accessor
+
+import (
+	"reflect"
+	"sync"
+)
+
+// LazyAccessor is the Accessor for a map or a slice which is converted
+// into an Accessor on the first access and cached,
+// created by NewAccessor with Lazy.
+// The conversion is done once even if it is read concurrently,
+// but it must not be modified concurrently like the other Accessors.
+type LazyAccessor struct {
+	value interface{}
+	acc   Accessor
+	err   error
+	opts  *options
+	once  sync.Once
+}
+
+// newChild creates the Accessor for a child of a map or a slice.
+func newChild(v interface{}, o *options) (Accessor, error) {
+	if !o.lazy {
+		return newAccessor(v, o)
+	}
+	if _, ok := v.(Accessor); ok {
+		return newAccessor(v, o)
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Map, reflect.Slice:
+		return &LazyAccessor{value: v, opts: o}, nil
+	default:
+		return newAccessor(v, o)
+	}
+}
+
+func (a *LazyAccessor) resolve() (Accessor, error) {
+	a.once.Do(func() {
+		a.acc, a.err = newAccessor(a.value, a.opts)
+		if a.err == nil {
+			a.value = nil
+		}
+	})
+	return a.acc, a.err
+}
+
+// Get implements Accessor.
+func (a *LazyAccessor) Get(path Path) (Accessor, error) {
+	if path == thePhantomPath {
+		return a, nil
+	}
+	acc, err := a.resolve()
+	if err != nil {
+		return nil, err
+	}
+	return acc.Get(path)
+}
+
+// Set implements Accessor.
+func (a *LazyAccessor) Set(path Path, value interface{}) error {
+	_, err := a.set(path, value)
+	return err
+}
+
+func (a *LazyAccessor) set(path Path, value interface{}) (Accessor, error) {
+	acc, err := a.resolve()
+	if err != nil {
+		return nil, err
+	}
+	acc, err = setTo(acc, path, value)
+	if err != nil {
+		return nil, err
+	}
+	a.acc = acc
+	return a, nil
+}
+
+func (a *LazyAccessor) setCreate(path Path, value interface{}) (Accessor, error) {
+	acc, err := a.resolve()
+	if err != nil {
+		return nil, err
+	}
+	acc, err = setCreateTo(acc, path, value)
+	if err != nil {
+		return nil, err
+	}
+	a.acc = acc
+	return a, nil
+}
+
+func (a *LazyAccessor) insert(path Path, value interface{}) (Accessor, error) {
+	acc, err := a.resolve()
+	if err != nil {
+		return nil, err
+	}
+	acc, err = insertTo(acc, path, value)
+	if err != nil {
+		return nil, err
+	}
+	a.acc = acc
+	return a, nil
+}
+
+// Delete implements Accessor.
+func (a *LazyAccessor) Delete(path Path) error {
+	_, err := a.delete(path)
+	return err
+}
+
+func (a *LazyAccessor) delete(path Path) (Accessor, error) {
+	acc, err := a.resolve()
+	if err != nil {
+		return nil, err
+	}
+	acc, err = deleteFrom(acc, path)
+	if err != nil {
+		return nil, err
+	}
+	a.acc = acc
+	return a, nil
+}
+
+// Unwrap implements Accessor.
+// It returns the original value if it cannot be converted.
+func (a *LazyAccessor) Unwrap() interface{} {
+	acc, err := a.resolve()
+	if err != nil {
+		return a.value
+	}
+	return acc.Unwrap()
+}
+
+// Foreach implements Accessor.
+func (a *LazyAccessor) Foreach(f func(path Path, value interface{}) error) error {
+	acc, err := a.resolve()
+	if err != nil {
+		return err
+	}
+	return acc.Foreach(f)
+}
+
+func (a *LazyAccessor) base() Accessor {
+	acc, err := a.resolve()
+	if err != nil {
+		return &ValueAccessor{a.value}
+	}
+	return acc
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (a *LazyAccessor) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// MarshalYAML implements gopkg.in/yaml.v2.Marshaler.
+func (a *LazyAccessor) MarshalYAML() (interface{}, error) {
+	return marshalYAML(a)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the JSON encoding.
+func (a *LazyAccessor) MarshalText() ([]byte, error) {
+	return marshalJSON(a)
+}
@@ -0,0 +1,130 @@
+package accessor
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccessor_Lazy(t *testing.T) {
+	assert := assert.New(t)
+
+	obj := map[string]interface{}{
+		"name": "me",
+		"friends": []interface{}{
+			map[string]interface{}{"name": "hello"},
+			map[string]interface{}{"name": "world"},
+		},
+		"keys": map[interface{}]interface{}{1: "a"},
+	}
+	acc, err := NewAccessor(obj, Lazy())
+	assert.Nil(err)
+
+	friends := acc.(MapAccessor)["friends"].(*LazyAccessor)
+	assert.Nil(friends.acc)
+
+	found, err := acc.Get(pathFromKeys([]string{"friends", "1", "name"}))
+	assert.Nil(err)
+	assert.Equal(&ValueAccessor{"world"}, found)
+	assert.NotNil(friends.acc)
+
+	assert.Nil(acc.Set(pathFromKeys([]string{"friends", "-"}), "foo"))
+	assert.Nil(acc.Delete(pathFromKeys([]string{"friends", "0"})))
+
+	_, err = acc.Get(pathFromKeys([]string{"keys", "1"}))
+	assert.Equal(NewInvalidKeyError(1), err)
+
+	eager, err := NewAccessor(map[string]interface{}{
+		"name": "me",
+		"friends": []interface{}{
+			map[string]interface{}{"name": "world"},
+			"foo",
+		},
+	})
+	assert.Nil(err)
+	delete(acc.(MapAccessor), "keys")
+	assert.True(Equal(eager, acc))
+	assert.Equal(eager.Unwrap(), acc.Unwrap())
+}
+
+func TestNewAccessor_LazyConcurrentGet(t *testing.T) {
+	assert := assert.New(t)
+
+	acc, err := NewAccessor(generateDocument(10), Lazy())
+	assert.Nil(err)
+
+	var wg sync.WaitGroup
+	names := make([]interface{}, 8)
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			found, err := acc.Get(pathFromKeys([]string{"items", "5", "name"}))
+			if err == nil {
+				names[i] = found.Unwrap()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, name := range names {
+		assert.Equal("item-5", name)
+	}
+}
+
+func generateDocument(n int) map[string]interface{} {
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = map[string]interface{}{
+			"id":   float64(i),
+			"name": fmt.Sprintf("item-%d", i),
+			"tags": []interface{}{"a", "b", "c"},
+			"attributes": map[string]interface{}{
+				"x": float64(i),
+				"y": true,
+			},
+		}
+	}
+	return map[string]interface{}{
+		"version": "1.0",
+		"items":   items,
+	}
+}
+
+func benchmarkGet(b *testing.B, n int, opts ...Option) {
+	doc := generateDocument(n)
+	name := pathFromKeys([]string{"version"})
+	item := pathFromKeys([]string{"items", fmt.Sprint(n / 2), "name"})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		acc, err := NewAccessor(doc, opts...)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := acc.Get(name); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := acc.Get(item); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGet_Eager_1000(b *testing.B) {
+	benchmarkGet(b, 1000)
+}
+
+func BenchmarkGet_Lazy_1000(b *testing.B) {
+	benchmarkGet(b, 1000, Lazy())
+}
+
+func BenchmarkGet_Eager_100000(b *testing.B) {
+	benchmarkGet(b, 100000)
+}
+
+func BenchmarkGet_Lazy_100000(b *testing.B) {
+	benchmarkGet(b, 100000, Lazy())
+}
@@ -0,0 +1,320 @@
+package accessor
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// LiveAccessor is the Accessor which reads and writes the original object
+// through reflection. Unlike the Accessor created by NewAccessor,
+// the object is not copied, and Set and Delete update the maps, slices
+// and structs in place, so that the existing references see the change.
+//
+// The fields of a struct are addressed in the same way as StructAccessor.
+type LiveAccessor struct {
+	value reflect.Value
+	ptr   reflect.Value
+	typ   reflect.Type
+	store func(reflect.Value)
+}
+
+// NewLiveAccessor creates a LiveAccessor from a pointer to the object.
+func NewLiveAccessor(ptr interface{}) (Accessor, error) {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, fmt.Errorf("cannot use %T(%v) as a pointer", ptr, ptr)
+	}
+	elem := rv.Elem()
+	return newLiveAccessor(elem, elem.Set), nil
+}
+
+// newLiveAccessor creates a LiveAccessor for the value held in a slot.
+// store stores a new value into the slot.
+func newLiveAccessor(v reflect.Value, store func(reflect.Value)) *LiveAccessor {
+	a := &LiveAccessor{
+		typ:   v.Type(),
+		store: store,
+	}
+	for (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr) && !v.IsNil() {
+		if v.Kind() == reflect.Ptr {
+			a.ptr = v
+		}
+		v = v.Elem()
+	}
+	a.value = v
+	return a
+}
+
+// Get implements Accessor.
+func (a *LiveAccessor) Get(path Path) (Accessor, error) {
+	if path == thePhantomPath {
+		return a, nil
+	}
+
+	child, err := a.child(path.Key())
+	if err != nil {
+		return nil, err
+	}
+	if a.omitted(path.Key()) {
+		return nil, NewNoSuchPathError("no such key", path.Key())
+	}
+	return getFromChild(child, path)
+}
+
+// Set implements Accessor.
+// "-" appends the value to a slice.
+// TypeMismatchError is returned when the value cannot be converted into the type of the destination.
+func (a *LiveAccessor) Set(path Path, value interface{}) error {
+	if acc, ok := value.(Accessor); ok {
+		value = acc.Unwrap()
+	}
+
+	if path == thePhantomPath {
+		rv, ok := convertValue(value, a.typ)
+		if !ok {
+			return NewTypeMismatchError(value, a.typ, path)
+		}
+		a.store(rv)
+		typ := a.typ
+		*a = *newLiveAccessor(rv, a.store)
+		a.typ = typ
+		return nil
+	}
+
+	sub, ok := path.SubPath()
+	if !ok && path.Key() == "-" && a.value.Kind() == reflect.Slice {
+		rv, ok := convertValue(value, a.value.Type().Elem())
+		if !ok {
+			return NewTypeMismatchError(value, a.value.Type().Elem(), path)
+		}
+		a.replace(reflect.Append(a.value, rv))
+		return nil
+	}
+
+	child, err := a.child(path.Key())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		sub = thePhantomPath
+	}
+	_, err = setToChild(child, value, path.Key(), sub)
+	return err
+}
+
+// Delete implements Accessor.
+func (a *LiveAccessor) Delete(path Path) error {
+	if sub, ok := path.SubPath(); ok {
+		child, err := a.child(path.Key())
+		if err != nil {
+			return err
+		}
+		_, err = deleteFromChild(child, path.Key(), sub)
+		return err
+	}
+
+	v := a.value
+	switch v.Kind() {
+	case reflect.Map:
+		k, ok := mapKey(v, path.Key())
+		if !ok {
+			return NewNoSuchPathError("no such key", path.Key())
+		}
+		v.SetMapIndex(k, reflect.Value{})
+		return nil
+	case reflect.Slice:
+		i, err := sliceIndex(path.Key(), v.Len(), false)
+		if err != nil {
+			return err
+		}
+		a.replace(reflect.AppendSlice(v.Slice(0, i), v.Slice(i+1, v.Len())))
+		return nil
+	case reflect.Array:
+		return NewNoSuchPathError("cannot delete an element from an array", path.Key())
+	case reflect.Struct:
+		if !isOpaque(v.Type()) {
+			return NewNoSuchPathError("cannot delete a field of a struct", path.Key())
+		}
+	}
+	return NewNoSuchPathError(fmt.Sprintf("%[1]T(%[1]v) has no key", a.Unwrap()), path.Key())
+}
+
+// Unwrap implements Accessor.
+// It returns the current value, or the pointer if the value is held by a pointer.
+func (a *LiveAccessor) Unwrap() interface{} {
+	if a.ptr.IsValid() {
+		return a.ptr.Interface()
+	}
+	return a.value.Interface()
+}
+
+// Foreach implements Accessor.
+// The keys of a map are enumerated in the sorted order,
+// and the fields of a struct are in the order of the declaration.
+func (a *LiveAccessor) Foreach(f func(path Path, value interface{}) error) error {
+	s, keys, err := a.list()
+	if err != nil {
+		return err
+	}
+	if s == shapeValue {
+		return f(thePhantomPath, a.Unwrap())
+	}
+
+	for _, key := range keys {
+		child, err := a.child(key)
+		if err != nil {
+			return err
+		}
+		if err := foreach(child, key, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *LiveAccessor) list() (shape, []string, error) {
+	v := a.value
+	switch v.Kind() {
+	case reflect.Map:
+		keys := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			key, ok := stringifyKey(k.Interface())
+			if !ok {
+				return shapeValue, nil, NewInvalidKeyError(k.Interface())
+			}
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return shapeMap, keys, nil
+	case reflect.Slice, reflect.Array:
+		return shapeSlice, indexKeys(v.Len()), nil
+	case reflect.Struct:
+		if isOpaque(v.Type()) {
+			return shapeValue, nil, nil
+		}
+		var keys []string
+		for _, sf := range structFields(v.Type()) {
+			if _, ok := fieldByIndex(v, sf.index); ok && !a.omitted(sf.name) {
+				keys = append(keys, sf.name)
+			}
+		}
+		return shapeMap, keys, nil
+	default:
+		return shapeValue, nil, nil
+	}
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (a *LiveAccessor) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// MarshalYAML implements gopkg.in/yaml.v2.Marshaler.
+func (a *LiveAccessor) MarshalYAML() (interface{}, error) {
+	return marshalYAML(a)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the JSON encoding.
+func (a *LiveAccessor) MarshalText() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// child returns the LiveAccessor for the value at the key.
+func (a *LiveAccessor) child(key string) (*LiveAccessor, error) {
+	v := a.value
+	switch v.Kind() {
+	case reflect.Map:
+		k, ok := mapKey(v, key)
+		if !ok {
+			return nil, NewNoSuchPathError("no such key", key)
+		}
+		return newLiveAccessor(v.MapIndex(k), func(nv reflect.Value) {
+			v.SetMapIndex(k, nv)
+		}), nil
+	case reflect.Slice, reflect.Array:
+		i, err := sliceIndex(key, v.Len(), false)
+		if err != nil {
+			return nil, err
+		}
+		return newLiveAccessor(v.Index(i), a.storeElem(func(c reflect.Value) reflect.Value {
+			return c.Index(i)
+		})), nil
+	case reflect.Struct:
+		if isOpaque(v.Type()) {
+			break
+		}
+		for _, sf := range structFields(v.Type()) {
+			if sf.name != key {
+				continue
+			}
+			fv, ok := fieldByIndex(v, sf.index)
+			if !ok {
+				break
+			}
+			index := sf.index
+			return newLiveAccessor(fv, a.storeElem(func(c reflect.Value) reflect.Value {
+				fv, _ := fieldByIndex(c, index)
+				return fv
+			})), nil
+		}
+		return nil, NewNoSuchPathError("no such key", key)
+	}
+	return nil, NewNoSuchPathError(fmt.Sprintf("%[1]T(%[1]v) has no key", a.Unwrap()), key)
+}
+
+// omitted reports whether the key is an empty field of a struct tagged with omitempty.
+func (a *LiveAccessor) omitted(key string) bool {
+	if a.value.Kind() != reflect.Struct {
+		return false
+	}
+	for _, sf := range structFields(a.value.Type()) {
+		if sf.name == key {
+			fv, ok := fieldByIndex(a.value, sf.index)
+			return ok && sf.omitEmpty && isEmptyValue(fv)
+		}
+	}
+	return false
+}
+
+// storeElem returns the function to store an element of the value,
+// which is located by locate.
+// A struct or an array held by a map or an interface is not addressable,
+// so it is copied, updated and stored into the slot again.
+func (a *LiveAccessor) storeElem(locate func(reflect.Value) reflect.Value) func(reflect.Value) {
+	return func(nv reflect.Value) {
+		if e := locate(a.value); e.CanSet() {
+			e.Set(nv)
+			return
+		}
+		c := reflect.New(a.value.Type()).Elem()
+		c.Set(a.value)
+		locate(c).Set(nv)
+		a.store(c)
+		a.value = reflect.ValueOf(c.Interface())
+	}
+}
+
+// replace replaces the value, such as a slice grown by append.
+func (a *LiveAccessor) replace(nv reflect.Value) {
+	if a.value.CanSet() {
+		a.value.Set(nv)
+		return
+	}
+	a.store(nv)
+	a.value = nv
+}
+
+// mapKey finds the key of the map which is addressed by the string.
+func mapKey(m reflect.Value, key string) (reflect.Value, bool) {
+	if k, ok := convertKey(key, m.Type().Key()); ok && m.MapIndex(k).IsValid() {
+		return k, true
+	}
+	for _, k := range m.MapKeys() {
+		if s, ok := stringifyKey(k.Interface()); ok && s == key {
+			return k, true
+		}
+	}
+	return reflect.Value{}, false
+}
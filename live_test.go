@@ -0,0 +1,307 @@
+package accessor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveAccessor_Set(t *testing.T) {
+	type Input struct {
+		Object func() interface{}
+		Path   string
+		Value  interface{}
+	}
+	type Expect struct {
+		Object interface{}
+		Err    error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "map",
+			Input: Input{
+				Object: func() interface{} {
+					return map[string]interface{}{
+						"friends": []interface{}{
+							map[string]interface{}{"name": "hello"},
+						},
+					}
+				},
+				Path:  "friends/0/name",
+				Value: "world",
+			},
+			Expect: Expect{
+				Object: map[string]interface{}{
+					"friends": []interface{}{
+						map[string]interface{}{"name": "world"},
+					},
+				},
+				Err: nil,
+			},
+		},
+		{
+			Title: "append in map",
+			Input: Input{
+				Object: func() interface{} {
+					return map[string]interface{}{"a": []interface{}{1}}
+				},
+				Path:  "a/-",
+				Value: 2,
+			},
+			Expect: Expect{
+				Object: map[string]interface{}{"a": []interface{}{1, 2}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "append to root",
+			Input: Input{
+				Object: func() interface{} {
+					return []int{1}
+				},
+				Path:  "-",
+				Value: 2,
+			},
+			Expect: Expect{
+				Object: []int{1, 2},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "struct",
+			Input: Input{
+				Object: func() interface{} {
+					return testConfig{Server: testServer{"localhost", 80}}
+				},
+				Path:  "server/port",
+				Value: 8080,
+			},
+			Expect: Expect{
+				Object: testConfig{Server: testServer{"localhost", 8080}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "embedded struct",
+			Input: Input{
+				Object: func() interface{} {
+					return testConfig{}
+				},
+				Path:  "version",
+				Value: 2,
+			},
+			Expect: Expect{
+				Object: testConfig{testMeta: testMeta{2}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "struct in map",
+			Input: Input{
+				Object: func() interface{} {
+					return map[string]testServer{"a": {"localhost", 80}}
+				},
+				Path:  "a/port",
+				Value: 8080,
+			},
+			Expect: Expect{
+				Object: map[string]testServer{"a": {"localhost", 8080}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "map in struct",
+			Input: Input{
+				Object: func() interface{} {
+					return testConfig{Labels: map[string]string{"a": "b"}}
+				},
+				Path:  "labels/a",
+				Value: "c",
+			},
+			Expect: Expect{
+				Object: testConfig{Labels: map[string]string{"a": "c"}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "type mismatch",
+			Input: Input{
+				Object: func() interface{} {
+					return map[string][]int{"a": {1}}
+				},
+				Path:  "a/0",
+				Value: "x",
+			},
+			Expect: Expect{
+				Object: map[string][]int{"a": {1}},
+				Err:    NewTypeMismatchError("x", reflect.TypeOf(0), pathFromKeys([]string{"a", "0"})),
+			},
+		},
+		{
+			Title: "no such key",
+			Input: Input{
+				Object: func() interface{} {
+					return map[string]int{"a": 1}
+				},
+				Path:  "b",
+				Value: 2,
+			},
+			Expect: Expect{
+				Object: map[string]int{"a": 1},
+				Err:    NewNoSuchPathError("no such key", "b"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			obj := testCase.Input.Object()
+			ptr := reflect.New(reflect.TypeOf(obj))
+			ptr.Elem().Set(reflect.ValueOf(obj))
+
+			acc, err := NewLiveAccessor(ptr.Interface())
+			assert.Nil(err)
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+
+			err = acc.Set(path, testCase.Input.Value)
+			assert.Equal(testCase.Expect.Err, err)
+			assert.Equal(testCase.Expect.Object, ptr.Elem().Interface())
+		})
+	}
+}
+
+func TestLiveAccessor_Delete(t *testing.T) {
+	assert := assert.New(t)
+
+	friends := []interface{}{"a", "b", "c"}
+	obj := map[string]interface{}{"friends": friends, "name": "me"}
+	acc, err := NewLiveAccessor(&obj)
+	assert.Nil(err)
+
+	assert.Nil(acc.Delete(pathFromKeys([]string{"friends", "1"})))
+	assert.Nil(acc.Delete(pathFromKeys([]string{"name"})))
+	assert.Equal(map[string]interface{}{"friends": []interface{}{"a", "c"}}, obj)
+	assert.Equal(
+		NewNoSuchPathError("cannot delete a field of a struct", "host"),
+		mustLiveAccessor(t, &testServer{}).Delete(pathFromKeys([]string{"host"})),
+	)
+}
+
+func TestLiveAccessor_Foreach(t *testing.T) {
+	assert := assert.New(t)
+
+	obj := map[string]interface{}{
+		"b": []interface{}{1, 2},
+		"a": &testServer{"localhost", 80},
+	}
+	acc := mustLiveAccessor(t, &obj)
+
+	var paths []string
+	err := acc.Foreach(func(path Path, value interface{}) error {
+		paths = append(paths, path.String())
+		return nil
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"a/host", "a/port", "b/0", "b/1"}, paths)
+
+	found, err := acc.Get(pathFromKeys([]string{"a"}))
+	assert.Nil(err)
+	assert.Equal(obj["a"], found.Unwrap())
+
+	expected := errors.New("stop")
+	assert.Equal(expected, acc.Foreach(func(path Path, value interface{}) error {
+		return expected
+	}))
+}
+
+func TestNewLiveAccessor(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewLiveAccessor(map[string]interface{}{})
+	assert.NotNil(err)
+
+	var ptr *testServer
+	_, err = NewLiveAccessor(ptr)
+	assert.NotNil(err)
+}
+
+func mustLiveAccessor(t *testing.T, ptr interface{}) Accessor {
+	acc, err := NewLiveAccessor(ptr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return acc
+}
+
+func TestLiveAccessor_Walk(t *testing.T) {
+	assert := assert.New(t)
+
+	obj := map[string]interface{}{
+		"a": []int{1, 2},
+		"b": &testServer{"localhost", 80},
+	}
+	acc := mustLiveAccessor(t, &obj)
+	plain := mustAccessor(t, map[string]interface{}{
+		"a": []interface{}{1, 2},
+		"b": map[string]interface{}{"host": "localhost", "port": 80},
+	})
+
+	assert.True(Equal(acc, plain))
+	assert.True(Equal(plain, acc))
+	assert.Equal(Hash(plain), Hash(acc))
+
+	pattern, err := ParseJSONPointer("/**")
+	assert.Nil(err)
+	matches, err := Query(acc, pattern)
+	assert.Nil(err)
+	var paths []string
+	for _, m := range matches {
+		paths = append(paths, m.Path.JSONPointer())
+	}
+	assert.Equal([]string{"", "/a", "/a/0", "/a/1", "/b", "/b/host", "/b/port"}, paths)
+
+	matches, err = QueryJSONPath(acc, "$.a[-1]")
+	assert.Nil(err)
+	assert.Len(matches, 1)
+	assert.Equal(2, matches[0].Accessor.Unwrap())
+
+	matches, err = QueryJSONPath(acc, "$.a[?length($.b) == 2]")
+	assert.Nil(err)
+	assert.Len(matches, 2)
+}
+
+func TestLiveAccessor_Clone(t *testing.T) {
+	assert := assert.New(t)
+
+	obj := map[string]interface{}{
+		"a": []int{1, 2},
+		"b": &testServer{"localhost", 80},
+	}
+	acc := mustLiveAccessor(t, &obj)
+
+	clone := Clone(acc)
+	assert.IsType(&LiveAccessor{}, clone)
+	assert.True(Equal(acc, clone))
+
+	assert.Nil(clone.Set(newPath("a", "0"), 3))
+	assert.Nil(clone.Set(newPath("b", "port"), 8080))
+	assert.Equal(map[string]interface{}{
+		"a": []int{1, 2},
+		"b": &testServer{"localhost", 80},
+	}, obj)
+	assert.Equal(map[string]interface{}{
+		"a": []int{3, 2},
+		"b": &testServer{"localhost", 8080},
+	}, clone.Unwrap())
+}
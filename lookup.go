@@ -0,0 +1,63 @@
+package accessor
+
+import (
+	"strconv"
+)
+
+// Has reports whether the path is present in the object.
+// A null is present, and an invalid path such as a key of a string is not.
+func Has(acc Accessor, path Path) bool {
+	_, ok, err := lookup(acc, path)
+	return ok && err == nil
+}
+
+// lookup finds the object by the path key by key.
+// It returns false with no error when the path is absent,
+// that is a key is missing in a map, an index is out of range, or a key of a null.
+func lookup(acc Accessor, path Path) (Accessor, bool, error) {
+	current := acc
+	for p, ok := path, path != thePhantomPath; ok; p, ok = p.SubPath() {
+		child, err := current.Get(thePhantomPath.PushKey(p.Key()))
+		if err == nil {
+			current = child
+			continue
+		}
+		if isAbsent(current, p.Key(), err) {
+			return nil, false, nil
+		}
+		// Get again to return the error with the full path.
+		_, err = acc.Get(path)
+		return nil, false, err
+	}
+	return current, true, nil
+}
+
+// isAbsent reports whether the error returned by Get of the key means the key is absent.
+func isAbsent(acc Accessor, key string, err error) bool {
+	if _, ok := err.(*NoSuchPathError); !ok {
+		return false
+	}
+
+	s, _, lerr := listOf(acc)
+	if lerr != nil {
+		return false
+	}
+	switch s {
+	case shapeMap:
+		return true
+	case shapeSlice:
+		_, aerr := strconv.Atoi(key)
+		return key == "-" || aerr == nil
+	default:
+		if acc.Unwrap() == nil {
+			return true
+		}
+		_, isLister := acc.(lister)
+		if !isLister {
+			_, isLister = baseOf(acc).(lister)
+		}
+		_, isValue := baseOf(acc).(*ValueAccessor)
+		// The Accessor implemented by others may not be a container.
+		return !isLister && !isValue
+	}
+}
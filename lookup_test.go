@@ -0,0 +1,131 @@
+package accessor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup_Existence(t *testing.T) {
+	acc, err := FromJSON(strings.NewReader(`{
+		"name": "me",
+		"nickname": null,
+		"friends": [{"name": "hello"}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type Input struct {
+		Accessor Accessor
+		Path     Path
+	}
+	type Expect struct {
+		Accessor Accessor
+		Found    bool
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "present",
+			Input: Input{acc, newPath("friends", "0", "name")},
+			Expect: Expect{
+				Accessor: &ValueAccessor{"hello"},
+				Found:    true,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "null",
+			Input: Input{acc, newPath("nickname")},
+			Expect: Expect{
+				Accessor: &ValueAccessor{nil},
+				Found:    true,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "missing key",
+			Input: Input{acc, newPath("friends", "0", "age")},
+			Expect: Expect{
+				Accessor: nil,
+				Found:    false,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "key of null",
+			Input: Input{acc, newPath("nickname", "first")},
+			Expect: Expect{
+				Accessor: nil,
+				Found:    false,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "index out of range",
+			Input: Input{acc, newPath("friends", "1", "name")},
+			Expect: Expect{
+				Accessor: nil,
+				Found:    false,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "missing field of struct",
+			Input: Input{mustAccessor(t, testConfig{}), newPath("backup")},
+			Expect: Expect{
+				Accessor: nil,
+				Found:    false,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "key of string",
+			Input: Input{acc, newPath("name", "first")},
+			Expect: Expect{
+				Accessor: nil,
+				Found:    false,
+				Err:      NewNoSuchPathError("string(me) has no key", "first", "name"),
+			},
+		},
+		{
+			Title: "not a number",
+			Input: Input{acc, newPath("friends", "first")},
+			Expect: Expect{
+				Accessor: nil,
+				Found:    false,
+				Err:      NewNoSuchPathError("not a number", "first", "friends"),
+			},
+		},
+		{
+			Title: "dummy",
+			Input: Input{MapAccessor{"a": DummyAccessor{1}}, newPath("a", "b")},
+			Expect: Expect{
+				Accessor: nil,
+				Found:    false,
+				Err:      fmt.Errorf("this is dummy accessor: 1"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			found, ok, err := Lookup[Accessor](testCase.Input.Accessor, testCase.Input.Path)
+			assert.Equal(testCase.Expect.Err, err)
+			assert.Equal(testCase.Expect.Found, ok)
+			assert.Equal(testCase.Expect.Accessor, found)
+			assert.Equal(testCase.Expect.Found, Has(testCase.Input.Accessor, testCase.Input.Path))
+		})
+	}
+}
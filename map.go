@@ -1,10 +1,18 @@
 package accessor
 
+import (
+	"sort"
+)
+
 // MapAccessor is the Accessor for a map.
 type MapAccessor map[string]Accessor
 
 // Get implements Accessor.
 func (a MapAccessor) Get(path Path) (Accessor, error) {
+	if path == thePhantomPath {
+		return a, nil
+	}
+
 	child, ok := a[path.Key()]
 	if !ok {
 		return nil, NewNoSuchPathError("no such key", path.Key())
@@ -30,7 +38,92 @@ func (a MapAccessor) Set(path Path, value interface{}) error {
 		return nil
 	}
 
-	return setToChild(child, value, path.Key(), sub)
+	child, err := setToChild(child, value, path.Key(), sub)
+	if err != nil {
+		return err
+	}
+	a[path.Key()] = child
+	return nil
+}
+
+// SetCreate set a object into specific path like Set,
+// but creates missing objects in the path instead of returning NoSuchPathError.
+// A missing object becomes a SliceAccessor when the next key is a number or "-",
+// otherwise a MapAccessor.
+func (a MapAccessor) SetCreate(path Path, value interface{}) error {
+	_, err := a.setCreate(path, value)
+	return err
+}
+
+func (a MapAccessor) setCreate(path Path, value interface{}) (Accessor, error) {
+	sub, ok := path.SubPath()
+	if !ok {
+		acc, err := NewAccessor(value)
+		if err != nil {
+			return nil, err
+		}
+		a[path.Key()] = acc
+		return a, nil
+	}
+
+	child, ok := a[path.Key()]
+	if !ok {
+		child = newContainer(sub.Key())
+	}
+
+	child, err := setCreateToChild(child, value, path.Key(), sub)
+	if err != nil {
+		return nil, err
+	}
+	a[path.Key()] = child
+	return a, nil
+}
+
+// Insert inserts a object into a slice at specific path and shifts the following elements.
+// NoSuchPathError is returned when the path is invalid or does not point an element of a slice.
+func (a MapAccessor) Insert(path Path, value interface{}) error {
+	_, err := a.insert(path, value)
+	return err
+}
+
+func (a MapAccessor) insert(path Path, value interface{}) (Accessor, error) {
+	child, ok := a[path.Key()]
+	if !ok {
+		return nil, NewNoSuchPathError("no such key", path.Key())
+	}
+
+	sub, ok := path.SubPath()
+	if !ok {
+		return nil, NewNoSuchPathError("cannot insert into a map", path.Key())
+	}
+
+	child, err := insertToChild(child, value, path.Key(), sub)
+	if err != nil {
+		return nil, err
+	}
+	a[path.Key()] = child
+	return a, nil
+}
+
+// Delete implements Accessor.
+func (a MapAccessor) Delete(path Path) error {
+	child, ok := a[path.Key()]
+	if !ok {
+		return NewNoSuchPathError("no such key", path.Key())
+	}
+
+	sub, ok := path.SubPath()
+	if !ok {
+		delete(a, path.Key())
+		return nil
+	}
+
+	child, err := deleteFromChild(child, path.Key(), sub)
+	if err != nil {
+		return err
+	}
+	a[path.Key()] = child
+	return nil
 }
 
 // Unwrap implements Accessor.
@@ -52,3 +145,28 @@ func (a MapAccessor) Foreach(f func(path Path, value interface{}) error) error {
 	}
 	return nil
 }
+
+func (a MapAccessor) list() (shape, []string, error) {
+	keys := make([]string, 0, len(a))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return shapeMap, keys, nil
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (a MapAccessor) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// MarshalYAML implements gopkg.in/yaml.v2.Marshaler.
+func (a MapAccessor) MarshalYAML() (interface{}, error) {
+	return marshalYAML(a)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the JSON encoding.
+func (a MapAccessor) MarshalText() ([]byte, error) {
+	return marshalJSON(a)
+}
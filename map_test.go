@@ -210,6 +210,375 @@ func TestMapAccessor_Set(t *testing.T) {
 	}
 }
 
+func TestMapAccessor_SetCreate(t *testing.T) {
+	type Input struct {
+		Accessor Accessor
+		Path     string
+		BeSet    Accessor
+	}
+	type Expect struct {
+		Accessor Accessor
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "success",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": DummyAccessor{1},
+				}),
+				Path:  "a",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": DummyAccessor{2},
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "create map",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{}),
+				Path:     "a/b/c",
+				BeSet:    DummyAccessor{1},
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": MapAccessor(map[string]Accessor{
+						"b": MapAccessor(map[string]Accessor{
+							"c": DummyAccessor{1},
+						}),
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "create slice",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{}),
+				Path:     "a/0/b/-",
+				BeSet:    DummyAccessor{1},
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": SliceAccessor([]Accessor{
+						MapAccessor(map[string]Accessor{
+							"b": SliceAccessor([]Accessor{
+								DummyAccessor{1},
+							}),
+						}),
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "append to existing slice",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": SliceAccessor([]Accessor{
+						DummyAccessor{1},
+					}),
+				}),
+				Path:  "a/1",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": SliceAccessor([]Accessor{
+						DummyAccessor{1},
+						DummyAccessor{2},
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "path error nested",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": MapAccessor(map[string]Accessor{
+						"b": SliceAccessor([]Accessor{
+							DummyAccessor{1},
+						}),
+					}),
+				}),
+				Path:  "a/b/2/c",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": MapAccessor(map[string]Accessor{
+						"b": SliceAccessor([]Accessor{
+							DummyAccessor{1},
+						}),
+					}),
+				}),
+				Err: NewNoSuchPathError("index out of range", "2", "b", "a"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+			acc := testCase.Input.Accessor
+			err = acc.(MapAccessor).SetCreate(path, testCase.Input.BeSet)
+
+			assert.Equal(testCase.Expect.Accessor, acc)
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+}
+
+func TestMapAccessor_Insert(t *testing.T) {
+	type Input struct {
+		Accessor Accessor
+		Path     string
+		BeSet    Accessor
+	}
+	type Expect struct {
+		Accessor Accessor
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "success",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"friends": SliceAccessor([]Accessor{
+						DummyAccessor{1},
+					}),
+				}),
+				Path:  "friends/0",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"friends": SliceAccessor([]Accessor{
+						DummyAccessor{2},
+						DummyAccessor{1},
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "append",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"friends": SliceAccessor([]Accessor{
+						DummyAccessor{1},
+					}),
+				}),
+				Path:  "friends/-",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"friends": SliceAccessor([]Accessor{
+						DummyAccessor{1},
+						DummyAccessor{2},
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "map",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": DummyAccessor{1},
+				}),
+				Path:  "a",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": DummyAccessor{1},
+				}),
+				Err: NewNoSuchPathError("cannot insert into a map", "a"),
+			},
+		},
+		{
+			Title: "path error",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": DummyAccessor{1},
+				}),
+				Path:  "x/0",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": DummyAccessor{1},
+				}),
+				Err: NewNoSuchPathError("no such key", "x"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+			acc := testCase.Input.Accessor
+			err = acc.(MapAccessor).Insert(path, testCase.Input.BeSet)
+
+			assert.Equal(testCase.Expect.Accessor, acc)
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+}
+
+func TestMapAccessor_Delete(t *testing.T) {
+	type Input struct {
+		Accessor Accessor
+		Path     string
+	}
+	type Expect struct {
+		Accessor Accessor
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "success",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": DummyAccessor{1},
+					"b": DummyAccessor{2},
+				}),
+				Path: "a",
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"b": DummyAccessor{2},
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "success nested",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": MapAccessor(map[string]Accessor{
+						"b": MapAccessor(map[string]Accessor{
+							"c": DummyAccessor{1},
+						}),
+					}),
+				}),
+				Path: "a/b/c",
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": MapAccessor(map[string]Accessor{
+						"b": MapAccessor(map[string]Accessor{}),
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "success slice element",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": SliceAccessor([]Accessor{
+						DummyAccessor{1},
+						DummyAccessor{2},
+					}),
+				}),
+				Path: "a/0",
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": SliceAccessor([]Accessor{
+						DummyAccessor{2},
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "path error",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": DummyAccessor{1},
+				}),
+				Path: "x",
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": DummyAccessor{1},
+				}),
+				Err: NewNoSuchPathError("no such key", "x"),
+			},
+		},
+		{
+			Title: "path error nested",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": MapAccessor(map[string]Accessor{
+						"b": SliceAccessor([]Accessor{
+							DummyAccessor{1},
+						}),
+					}),
+				}),
+				Path: "a/b/1",
+			},
+			Expect: Expect{
+				Accessor: MapAccessor(map[string]Accessor{
+					"a": MapAccessor(map[string]Accessor{
+						"b": SliceAccessor([]Accessor{
+							DummyAccessor{1},
+						}),
+					}),
+				}),
+				Err: NewNoSuchPathError("index out of range", "1", "b", "a"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+			acc := testCase.Input.Accessor
+			err = acc.Delete(path)
+
+			assert.Equal(testCase.Expect.Accessor, acc)
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+}
+
 func TestMapAccessor_Unwrap(t *testing.T) {
 	type Input struct {
 		Accessor Accessor
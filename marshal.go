@@ -0,0 +1,274 @@
+package accessor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// EncodeOption is an option for ToJSON, ToYAML and ToTOML.
+type EncodeOption func(*encodeOptions)
+
+type encodeOptions struct {
+	sortKeys bool
+	indented bool
+	prefix   string
+	indent   string
+}
+
+// SortKeys sorts the keys of all objects.
+// The keys of a MapAccessor are always sorted, but the fields of a struct
+// are encoded in the order of the declaration without this option.
+func SortKeys() EncodeOption {
+	return func(o *encodeOptions) {
+		o.sortKeys = true
+	}
+}
+
+// Indent indents the output like json.Indent.
+// The prefix is ignored by ToTOML, and ToYAML always indents by two spaces.
+func Indent(prefix, indent string) EncodeOption {
+	return func(o *encodeOptions) {
+		o.indented = true
+		o.prefix = prefix
+		o.indent = indent
+	}
+}
+
+// ToJSON encodes the object into a JSON document.
+func ToJSON(w io.Writer, acc Accessor, opts ...EncodeOption) error {
+	bs, err := newEncodeOptions(opts).marshalJSON(acc)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(bs, '\n'))
+	return err
+}
+
+// ToYAML encodes the object into a YAML document.
+// The original keys of a KeyedMapAccessor are restored.
+func ToYAML(w io.Writer, acc Accessor, opts ...EncodeOption) error {
+	v, err := newEncodeOptions(opts).toYAML(acc)
+	if err != nil {
+		return err
+	}
+	bs, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bs)
+	return err
+}
+
+// ToTOML encodes the object into a TOML document.
+// The object must be a map.
+// Use this instead of encoding the Accessor directly with the toml package,
+// which encodes an Accessor as a string by MarshalText.
+func ToTOML(w io.Writer, acc Accessor, opts ...EncodeOption) error {
+	o := newEncodeOptions(opts)
+	v, err := o.toPlain(acc)
+	if err != nil {
+		return err
+	}
+	enc := toml.NewEncoder(w)
+	if o.indented {
+		enc.Indent = o.indent
+	}
+	return enc.Encode(v)
+}
+
+func newEncodeOptions(opts []EncodeOption) *encodeOptions {
+	o := &encodeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *encodeOptions) list(acc Accessor) (shape, []string, error) {
+	if o.sortKeys {
+		return sortedListOf(acc)
+	}
+	return listOf(acc)
+}
+
+func (o *encodeOptions) marshalJSON(acc Accessor) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := o.writeJSON(buf, acc); err != nil {
+		return nil, err
+	}
+	if !o.indented {
+		return buf.Bytes(), nil
+	}
+
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, buf.Bytes(), o.prefix, o.indent); err != nil {
+		return nil, err
+	}
+	return indented.Bytes(), nil
+}
+
+func (o *encodeOptions) writeJSON(buf *bytes.Buffer, acc Accessor) error {
+	s, keys, err := o.list(acc)
+	if err != nil {
+		return err
+	}
+
+	switch s {
+	case shapeMap, shapeSlice:
+		begin, end := byte('{'), byte('}')
+		if s == shapeSlice {
+			begin, end = '[', ']'
+		}
+		buf.WriteByte(begin)
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if s == shapeMap {
+				bs, err := json.Marshal(k)
+				if err != nil {
+					return err
+				}
+				buf.Write(bs)
+				buf.WriteByte(':')
+			}
+			child, err := childOf(acc, k)
+			if err != nil {
+				return err
+			}
+			if err := o.writeJSON(buf, child); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(end)
+		return nil
+	default:
+		bs, err := json.Marshal(acc.Unwrap())
+		if err != nil {
+			return err
+		}
+		buf.Write(bs)
+		return nil
+	}
+}
+
+// toYAML converts the object into a value for gopkg.in/yaml.v2,
+// where a map becomes yaml.MapSlice to keep the order of the keys.
+func (o *encodeOptions) toYAML(acc Accessor) (interface{}, error) {
+	s, keys, err := o.list(acc)
+	if err != nil {
+		return nil, err
+	}
+
+	switch s {
+	case shapeMap:
+		result := make(yaml.MapSlice, len(keys))
+		for i, k := range keys {
+			child, err := childOf(acc, k)
+			if err != nil {
+				return nil, err
+			}
+			v, err := o.toYAML(child)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = yaml.MapItem{Key: originalKey(acc, k), Value: v}
+		}
+		return result, nil
+	case shapeSlice:
+		result := make([]interface{}, len(keys))
+		for i, k := range keys {
+			child, err := childOf(acc, k)
+			if err != nil {
+				return nil, err
+			}
+			v, err := o.toYAML(child)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = v
+		}
+		return result, nil
+	default:
+		return acc.Unwrap(), nil
+	}
+}
+
+// toPlain converts the object into map[string]interface{}, []interface{} and values.
+func (o *encodeOptions) toPlain(acc Accessor) (interface{}, error) {
+	s, keys, err := o.list(acc)
+	if err != nil {
+		return nil, err
+	}
+
+	switch s {
+	case shapeMap:
+		result := make(map[string]interface{}, len(keys))
+		for _, k := range keys {
+			child, err := childOf(acc, k)
+			if err != nil {
+				return nil, err
+			}
+			v, err := o.toPlain(child)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = v
+		}
+		return result, nil
+	case shapeSlice:
+		result := make([]interface{}, len(keys))
+		for i, k := range keys {
+			child, err := childOf(acc, k)
+			if err != nil {
+				return nil, err
+			}
+			v, err := o.toPlain(child)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = v
+		}
+		return result, nil
+	default:
+		return acc.Unwrap(), nil
+	}
+}
+
+// originalKey returns the key before stringified by StringifyKeys.
+func originalKey(acc Accessor, key string) interface{} {
+	for {
+		if km, ok := acc.(KeyedMapAccessor); ok {
+			if k, ok := km.Keys[key]; ok {
+				return k
+			}
+			return key
+		}
+		w, ok := acc.(wrapper)
+		if !ok {
+			return key
+		}
+		acc = w.base()
+	}
+}
+
+func marshalJSON(acc Accessor) ([]byte, error) {
+	return (&encodeOptions{}).marshalJSON(acc)
+}
+
+func marshalYAML(acc Accessor) (interface{}, error) {
+	return (&encodeOptions{}).toYAML(acc)
+}
+
+func indexKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprint(i)
+	}
+	return keys
+}
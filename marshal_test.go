@@ -0,0 +1,149 @@
+package accessor
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestTo(t *testing.T) {
+	type Input struct {
+		Encode   func(w io.Writer, acc Accessor, opts ...EncodeOption) error
+		Accessor Accessor
+		Options  []EncodeOption
+	}
+	type Expect struct {
+		Text string
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	keyed, err := NewAccessor(map[interface{}]interface{}{1: "a", "b": []interface{}{true}}, StringifyKeys())
+	assert.Nil(t, err)
+
+	table := []Test{
+		{
+			Title: "json",
+			Input: Input{
+				Encode: ToJSON,
+				Accessor: MapAccessor{
+					"b": SliceAccessor{&ValueAccessor{1}, DummyAccessor{2}},
+					"a": &ValueAccessor{"x"},
+				},
+				Options: nil,
+			},
+			Expect: Expect{
+				Text: `{"a":"x","b":[1,2]}` + "\n",
+			},
+		},
+		{
+			Title: "json indent",
+			Input: Input{
+				Encode: ToJSON,
+				Accessor: MapAccessor{
+					"a": SliceAccessor{&ValueAccessor{1}},
+				},
+				Options: []EncodeOption{Indent("", "  ")},
+			},
+			Expect: Expect{
+				Text: "{\n  \"a\": [\n    1\n  ]\n}\n",
+			},
+		},
+		{
+			Title: "struct",
+			Input: Input{
+				Encode:   ToJSON,
+				Accessor: mustAccessor(t, testServer{"localhost", 80}),
+				Options:  nil,
+			},
+			Expect: Expect{
+				Text: `{"host":"localhost","port":80}` + "\n",
+			},
+		},
+		{
+			Title: "struct sorted",
+			Input: Input{
+				Encode:   ToJSON,
+				Accessor: mustAccessor(t, struct{ B, A int }{1, 2}),
+				Options:  []EncodeOption{SortKeys()},
+			},
+			Expect: Expect{
+				Text: `{"A":2,"B":1}` + "\n",
+			},
+		},
+		{
+			Title: "yaml",
+			Input: Input{
+				Encode:   ToYAML,
+				Accessor: keyed,
+				Options:  nil,
+			},
+			Expect: Expect{
+				Text: "1: a\nb:\n- true\n",
+			},
+		},
+		{
+			Title: "toml",
+			Input: Input{
+				Encode: ToTOML,
+				Accessor: MapAccessor{
+					"a": &ValueAccessor{int64(1)},
+					"b": MapAccessor{"c": &ValueAccessor{"x"}},
+				},
+				Options: []EncodeOption{Indent("", "")},
+			},
+			Expect: Expect{
+				Text: "a = 1\n\n[b]\nc = \"x\"\n",
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			buf := &bytes.Buffer{}
+			err := testCase.Input.Encode(buf, testCase.Input.Accessor, testCase.Input.Options...)
+			assert.Nil(err)
+			assert.Equal(testCase.Expect.Text, buf.String())
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	acc, err := NewAccessor(map[string]interface{}{
+		"a": []interface{}{1, "x"},
+		"b": map[string]interface{}{"c": nil},
+	}, Lazy())
+	assert.Nil(err)
+	acc.(MapAccessor)["d"] = DummyAccessor{3}
+
+	bs, err := json.Marshal(acc)
+	assert.Nil(err)
+	assert.Equal(`{"a":[1,"x"],"b":{"c":null},"d":3}`, string(bs))
+
+	bs, err = yaml.Marshal(acc)
+	assert.Nil(err)
+	assert.Equal("a:\n- 1\n- x\nb:\n  c: null\nd: 3\n", string(bs))
+
+	bs, err = acc.(MapAccessor).MarshalText()
+	assert.Nil(err)
+	assert.Equal(`{"a":[1,"x"],"b":{"c":null},"d":3}`, string(bs))
+}
+
+func mustAccessor(t *testing.T, v interface{}) Accessor {
+	acc, err := NewAccessor(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return acc
+}
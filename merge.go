@@ -0,0 +1,172 @@
+package accessor
+
+import (
+	"reflect"
+)
+
+// MergePatch applies a RFC 7386 JSON Merge Patch to the target and returns the result.
+// A null in the patch deletes the key from the target,
+// or sets the zero value to the field of a struct which cannot be deleted.
+// Neither the target nor the patch is modified.
+func MergePatch(target Accessor, patch Accessor) (Accessor, error) {
+	return mergePatch(Clone(target), patch)
+}
+
+func mergePatch(target Accessor, patch Accessor) (Accessor, error) {
+	ps, keys, err := listOf(patch)
+	if err != nil {
+		return nil, err
+	}
+	if ps != shapeMap {
+		return Clone(patch), nil
+	}
+
+	ts, _, err := listOf(target)
+	if err != nil {
+		return nil, err
+	}
+	if ts != shapeMap {
+		target = MapAccessor{}
+	}
+
+	for _, k := range keys {
+		v, err := childOf(patch, k)
+		if err != nil {
+			return nil, err
+		}
+		if isNull(v) {
+			target, err = clearKey(target, k)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		child, err := childOf(target, k)
+		if err != nil {
+			if !isAbsent(target, k, err) {
+				return nil, err
+			}
+			child = &ValueAccessor{nil}
+		}
+		merged, err := mergePatch(child, v)
+		if err != nil {
+			return nil, err
+		}
+		target, err = setCreateTo(target, thePhantomPath.PushKey(k), merged)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return target, nil
+}
+
+// clearKey deletes the key from the object,
+// or sets the zero value to the field if the object is a struct.
+func clearKey(acc Accessor, key string) (Accessor, error) {
+	path := thePhantomPath.PushKey(key)
+	child, err := acc.Get(path)
+	if err != nil {
+		if isAbsent(acc, key, err) {
+			return acc, nil
+		}
+		return nil, err
+	}
+
+	if !isStruct(acc) {
+		return deleteFrom(acc, path)
+	}
+	v := child.Unwrap()
+	if v == nil {
+		return acc, nil
+	}
+	return setTo(acc, path, reflect.Zero(reflect.TypeOf(v)).Interface())
+}
+
+// CreateMergePatch creates a RFC 7386 JSON Merge Patch which transforms
+// the original into the modified.
+// A null in the modified map cannot be represented because null means delete.
+func CreateMergePatch(original, modified Accessor) (Accessor, error) {
+	oshape, okeys, err := listOf(original)
+	if err != nil {
+		return nil, err
+	}
+	mshape, mkeys, err := listOf(modified)
+	if err != nil {
+		return nil, err
+	}
+	if oshape != shapeMap || mshape != shapeMap {
+		return Clone(modified), nil
+	}
+
+	patch := MapAccessor{}
+	inModified := make(map[string]bool, len(mkeys))
+	for _, k := range mkeys {
+		inModified[k] = true
+	}
+	for _, k := range okeys {
+		if !inModified[k] {
+			patch[k] = &ValueAccessor{nil}
+		}
+	}
+
+	inOriginal := make(map[string]bool, len(okeys))
+	for _, k := range okeys {
+		inOriginal[k] = true
+	}
+	for _, k := range mkeys {
+		mv, err := childOf(modified, k)
+		if err != nil {
+			return nil, err
+		}
+		if !inOriginal[k] {
+			patch[k] = Clone(mv)
+			continue
+		}
+		ov, err := childOf(original, k)
+		if err != nil {
+			return nil, err
+		}
+
+		oshape, _, err := listOf(ov)
+		if err != nil {
+			return nil, err
+		}
+		mshape, _, err := listOf(mv)
+		if err != nil {
+			return nil, err
+		}
+		if oshape == shapeMap && mshape == shapeMap {
+			sub, err := CreateMergePatch(ov, mv)
+			if err != nil {
+				return nil, err
+			}
+			if len(sub.(MapAccessor)) > 0 {
+				patch[k] = sub
+			}
+			continue
+		}
+
+		if !Equal(ov, mv, IgnoreNumericTypes()) {
+			patch[k] = Clone(mv)
+		}
+	}
+	return patch, nil
+}
+
+// isStruct reports whether the object is a struct, whose fields cannot be deleted.
+func isStruct(acc Accessor) bool {
+	switch a := baseOf(acc).(type) {
+	case *StructAccessor:
+		return true
+	case *LiveAccessor:
+		return a.value.Kind() == reflect.Struct && !isOpaque(a.value.Type())
+	default:
+		return false
+	}
+}
+
+func isNull(acc Accessor) bool {
+	v, ok := acc.(*ValueAccessor)
+	return ok && v.Value == nil
+}
@@ -0,0 +1,199 @@
+package accessor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	type Input struct {
+		Target string
+		Patch  string
+	}
+	type Expect struct {
+		Result string
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "replace",
+			Input: Input{
+				Target: `{"a": "b"}`,
+				Patch:  `{"a": "c"}`,
+			},
+			Expect: Expect{
+				Result: `{"a": "c"}`,
+			},
+		},
+		{
+			Title: "delete",
+			Input: Input{
+				Target: `{"a": "b", "b": "c"}`,
+				Patch:  `{"a": null}`,
+			},
+			Expect: Expect{
+				Result: `{"b": "c"}`,
+			},
+		},
+		{
+			Title: "nested",
+			Input: Input{
+				Target: `{"a": {"b": "c", "d": "e"}, "f": [1]}`,
+				Patch:  `{"a": {"b": "x", "d": null, "g": {"h": null}}, "f": [2, 3]}`,
+			},
+			Expect: Expect{
+				Result: `{"a": {"b": "x", "g": {}}, "f": [2, 3]}`,
+			},
+		},
+		{
+			Title: "not an object",
+			Input: Input{
+				Target: `{"a": "b"}`,
+				Patch:  `["c"]`,
+			},
+			Expect: Expect{
+				Result: `["c"]`,
+			},
+		},
+		{
+			Title: "object into value",
+			Input: Input{
+				Target: `{"a": "b"}`,
+				Patch:  `{"a": {"c": 1}}`,
+			},
+			Expect: Expect{
+				Result: `{"a": {"c": 1}}`,
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			target := mustJSONAccessor(t, testCase.Input.Target)
+			original := target.Unwrap()
+			patch := mustJSONAccessor(t, testCase.Input.Patch)
+
+			result, err := MergePatch(target, patch)
+			assert.Nil(err)
+
+			assert.Equal(mustJSONAccessor(t, testCase.Expect.Result).Unwrap(), result.Unwrap())
+			assert.Equal(original, target.Unwrap())
+		})
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	type Input struct {
+		Original string
+		Modified string
+	}
+	type Expect struct {
+		Patch string
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "no change",
+			Input: Input{
+				Original: `{"a": {"b": 1}, "c": [1]}`,
+				Modified: `{"a": {"b": 1}, "c": [1]}`,
+			},
+			Expect: Expect{
+				Patch: `{}`,
+			},
+		},
+		{
+			Title: "changes",
+			Input: Input{
+				Original: `{"a": {"b": 1, "c": 2}, "d": [1], "e": "x"}`,
+				Modified: `{"a": {"b": 1, "c": 3, "f": 4}, "d": [1, 2]}`,
+			},
+			Expect: Expect{
+				Patch: `{"a": {"c": 3, "f": 4}, "d": [1, 2], "e": null}`,
+			},
+		},
+		{
+			Title: "not an object",
+			Input: Input{
+				Original: `{"a": 1}`,
+				Modified: `[1]`,
+			},
+			Expect: Expect{
+				Patch: `[1]`,
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			original := mustJSONAccessor(t, testCase.Input.Original)
+			modified := mustJSONAccessor(t, testCase.Input.Modified)
+
+			patch, err := CreateMergePatch(original, modified)
+			assert.Nil(err)
+			assert.Equal(mustJSONAccessor(t, testCase.Expect.Patch).Unwrap(), patch.Unwrap())
+
+			result, err := MergePatch(original, patch)
+			assert.Nil(err)
+			assert.Equal(modified.Unwrap(), result.Unwrap())
+		})
+	}
+}
+
+func mustJSONAccessor(t *testing.T, text string) Accessor {
+	var obj interface{}
+	err := json.Unmarshal([]byte(text), &obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc, err := NewAccessor(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return acc
+}
+
+func TestMergePatch_Struct(t *testing.T) {
+	assert := assert.New(t)
+
+	config := &testConfig{Name: "x", Labels: map[string]string{"a": "b"}, Ports: []int{80}}
+	acc := mustAccessor(t, config)
+
+	result, err := MergePatch(acc, mustJSONAccessor(t, `{"name": "y", "labels": null, "server": {"port": 8080}}`))
+	assert.Nil(err)
+	assert.Equal(&testConfig{Name: "y", Server: testServer{Port: 8080}, Ports: []int{80}}, result.Unwrap())
+	assert.Equal(&testConfig{Name: "x", Labels: map[string]string{"a": "b"}, Ports: []int{80}}, config)
+
+	_, err = MergePatch(acc, mustJSONAccessor(t, `{"unknown": 1}`))
+	assert.Equal(NewNoSuchPathError("no such key", "unknown"), err)
+}
+
+func TestCreateMergePatch_Live(t *testing.T) {
+	assert := assert.New(t)
+
+	obj := map[string]interface{}{"a": 1, "b": map[string]int{"c": 2, "d": 3}}
+	original := mustLiveAccessor(t, &obj)
+	modified := mustJSONAccessor(t, `{"a": 1, "b": {"c": 4}}`)
+
+	patch, err := CreateMergePatch(original, modified)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{
+		"b": map[string]interface{}{"c": float64(4), "d": nil},
+	}, patch.Unwrap())
+}
@@ -0,0 +1,251 @@
+package accessor
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// OrderedMapAccessor is the Accessor for a map which remembers the order of the keys.
+// It is created from yaml.MapSlice, or by FromJSON and FromYAML with PreserveOrder.
+// Foreach and the marshalers visit the keys in the order, and a key added by SetCreate
+// comes last. A duplicated key in the document takes the last value at the position
+// of the first one, as the last value wins without PreserveOrder.
+// Unwrap returns map[string]interface{}, so use the marshalers to keep the order.
+type OrderedMapAccessor struct {
+	Map  MapAccessor
+	Keys []string
+}
+
+// PreserveOrder makes FromJSON and FromYAML create an OrderedMapAccessor for each map
+// to keep the order of the keys in the document.
+func PreserveOrder() Option {
+	return func(o *options) {
+		o.preserveOrder = true
+	}
+}
+
+func newOrderedMapAccessor(ms yaml.MapSlice, o *options) (Accessor, error) {
+	a := &OrderedMapAccessor{
+		Map:  MapAccessor{},
+		Keys: make([]string, 0, len(ms)),
+	}
+	for _, item := range ms {
+		key, ok := item.Key.(string)
+		if !ok && o.stringifyKeys {
+			key, ok = stringifyKey(item.Key)
+		}
+		if !ok {
+			return nil, NewInvalidKeyError(item.Key)
+		}
+		child, err := newChild(item.Value, o)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := a.Map[key]; !dup {
+			a.Keys = append(a.Keys, key)
+		}
+		a.Map[key] = child
+	}
+	return a, nil
+}
+
+// Get implements Accessor.
+func (a *OrderedMapAccessor) Get(path Path) (Accessor, error) {
+	if path == thePhantomPath {
+		return a, nil
+	}
+	return a.Map.Get(path)
+}
+
+// Set implements Accessor.
+func (a *OrderedMapAccessor) Set(path Path, value interface{}) error {
+	return a.Map.Set(path, value)
+}
+
+// SetCreate is same as MapAccessor.SetCreate.
+func (a *OrderedMapAccessor) SetCreate(path Path, value interface{}) error {
+	_, err := a.setCreate(path, value)
+	return err
+}
+
+func (a *OrderedMapAccessor) setCreate(path Path, value interface{}) (Accessor, error) {
+	_, exists := a.Map[path.Key()]
+	_, err := a.Map.setCreate(path, value)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		a.Keys = append(a.Keys, path.Key())
+	}
+	return a, nil
+}
+
+// Insert is same as MapAccessor.Insert.
+func (a *OrderedMapAccessor) Insert(path Path, value interface{}) error {
+	return a.Map.Insert(path, value)
+}
+
+func (a *OrderedMapAccessor) insert(path Path, value interface{}) (Accessor, error) {
+	_, err := a.Map.insert(path, value)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Delete implements Accessor.
+func (a *OrderedMapAccessor) Delete(path Path) error {
+	err := a.Map.Delete(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := path.SubPath(); !ok {
+		a.Keys = a.keys()
+	}
+	return nil
+}
+
+// Unwrap implements Accessor.
+func (a *OrderedMapAccessor) Unwrap() interface{} {
+	return a.Map.Unwrap()
+}
+
+// Foreach implements Accessor.
+// The keys are visited in the order.
+func (a *OrderedMapAccessor) Foreach(f func(path Path, value interface{}) error) error {
+	for _, k := range a.keys() {
+		if err := foreach(a.Map[k], k, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *OrderedMapAccessor) base() Accessor {
+	return a.Map
+}
+
+func (a *OrderedMapAccessor) list() (shape, []string, error) {
+	return shapeMap, a.keys(), nil
+}
+
+// keys returns the keys in the order.
+// The keys added to Map directly, such as by MergePatch, follow in the sorted order.
+func (a *OrderedMapAccessor) keys() []string {
+	keys := make([]string, 0, len(a.Map))
+	seen := make(map[string]bool, len(a.Map))
+	for _, k := range a.Keys {
+		if _, ok := a.Map[k]; ok && !seen[k] {
+			keys = append(keys, k)
+			seen[k] = true
+		}
+	}
+
+	var rest []string
+	for k := range a.Map {
+		if !seen[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (a *OrderedMapAccessor) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// MarshalYAML implements gopkg.in/yaml.v2.Marshaler.
+func (a *OrderedMapAccessor) MarshalYAML() (interface{}, error) {
+	return marshalYAML(a)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the JSON encoding.
+func (a *OrderedMapAccessor) MarshalText() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// decodeOrderedJSON decodes a JSON document by tokens,
+// where an object becomes yaml.MapSlice to keep the order of the keys.
+func decodeOrderedJSON(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		ms := yaml.MapSlice{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			ms = append(ms, yaml.MapItem{Key: key, Value: v})
+		}
+		_, err := dec.Token()
+		return ms, err
+	case json.Delim('['):
+		s := []interface{}{}
+		for dec.More() {
+			v, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+		_, err := dec.Token()
+		return s, err
+	default:
+		return tok, nil
+	}
+}
+
+// orderedYAML decodes a YAML document where a mapping becomes yaml.MapSlice.
+// gopkg.in/yaml.v2 decodes the nested mappings into yaml.MapSlice
+// once the outer one is decoded into it, so only the sequences need to be handled.
+type orderedYAML struct {
+	value interface{}
+}
+
+func (y *orderedYAML) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var ms yaml.MapSlice
+	if err := unmarshal(&ms); err == nil {
+		y.value = ms
+		return nil
+	}
+
+	var seq []orderedYAML
+	if err := unmarshal(&seq); err == nil {
+		result := make([]interface{}, len(seq))
+		for i, v := range seq {
+			result[i] = v.value
+		}
+		y.value = result
+		return nil
+	}
+
+	return unmarshal(&y.value)
+}
+
+func decodeOrderedYAML(r io.Reader) (interface{}, error) {
+	bs, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var y orderedYAML
+	if err := yaml.Unmarshal(bs, &y); err != nil {
+		return nil, err
+	}
+	return y.value, nil
+}
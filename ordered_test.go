@@ -0,0 +1,114 @@
+package accessor
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestFromJSON_PreserveOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	text := `{"z":1,"a":{"y":true,"b":null},"list":[{"q":"x","p":[]}]}`
+	acc, err := FromJSON(strings.NewReader(text), PreserveOrder())
+	assert.Nil(err)
+
+	bs, err := json.Marshal(acc)
+	assert.Nil(err)
+	assert.Equal(text, string(bs))
+
+	var paths []string
+	err = acc.Foreach(func(path Path, value interface{}) error {
+		paths = append(paths, path.String())
+		return nil
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"z", "a/y", "a/b", "list/0/q"}, paths)
+
+	buf := &bytes.Buffer{}
+	err = ToJSON(buf, acc, SortKeys())
+	assert.Nil(err)
+	assert.Equal(`{"a":{"b":null,"y":true},"list":[{"p":[],"q":"x"}],"z":1}`+"\n", buf.String())
+}
+
+func TestFromYAML_PreserveOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	text := "- z: 1\n  a:\n    w: true\n    b: [x]\n- c\n"
+	acc, err := FromYAML(strings.NewReader(text), PreserveOrder())
+	assert.Nil(err)
+
+	bs, err := yaml.Marshal(acc)
+	assert.Nil(err)
+	assert.Equal("- z: 1\n  a:\n    w: true\n    b:\n    - x\n- c\n", string(bs))
+}
+
+func TestFrom_PreserveOrderDuplicatedKey(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, from := range []func(r io.Reader, opts ...Option) (Accessor, error){FromJSON, FromYAML} {
+		plain, err := from(strings.NewReader(`{"a": 1, "b": 2, "a": 3}`))
+		assert.Nil(err)
+		acc, err := from(strings.NewReader(`{"a": 1, "b": 2, "a": 3}`), PreserveOrder())
+		assert.Nil(err)
+
+		assert.True(Equal(plain, acc, IgnoreNumericTypes()))
+		bs, err := json.Marshal(acc)
+		assert.Nil(err)
+		assert.Equal(`{"a":3,"b":2}`, string(bs))
+	}
+}
+
+func TestApplyPatch_PreserveOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	acc, err := FromJSON(strings.NewReader(`{"b":1,"a":2,"c":3}`), PreserveOrder())
+	assert.Nil(err)
+
+	err = ApplyPatch(acc, []byte(`[
+		{"op": "remove", "path": "/b"},
+		{"op": "add", "path": "/b", "value": 9},
+		{"op": "add", "path": "/0", "value": 0}
+	]`))
+	assert.Nil(err)
+
+	bs, err := json.Marshal(acc)
+	assert.Nil(err)
+	assert.Equal(`{"a":2,"c":3,"b":9,"0":0}`, string(bs))
+}
+
+func TestOrderedMapAccessor(t *testing.T) {
+	assert := assert.New(t)
+
+	acc, err := NewAccessor(yaml.MapSlice{
+		{Key: "b", Value: 1},
+		{Key: "a", Value: yaml.MapSlice{{Key: "d", Value: 2}, {Key: "c", Value: 3}}},
+	})
+	assert.Nil(err)
+	om := acc.(*OrderedMapAccessor)
+
+	assert.Nil(om.SetCreate(pathFromKeys([]string{"0"}), 4))
+	assert.Nil(om.SetCreate(pathFromKeys([]string{"a", "b"}), 5))
+	assert.Nil(om.Set(pathFromKeys([]string{"b"}), 6))
+	assert.Nil(om.Delete(pathFromKeys([]string{"a", "d"})))
+
+	bs, err := json.Marshal(om)
+	assert.Nil(err)
+	assert.Equal(`{"b":6,"a":{"c":3,"b":5},"0":4}`, string(bs))
+	assert.Equal(map[string]interface{}{
+		"b": 6,
+		"a": map[string]interface{}{"c": 3, "b": 5},
+		"0": 4,
+	}, om.Unwrap())
+
+	assert.Nil(om.Delete(pathFromKeys([]string{"b"})))
+	assert.Equal([]string{"a", "0"}, om.Keys)
+
+	_, err = NewAccessor(yaml.MapSlice{{Key: 1, Value: "a"}})
+	assert.Equal(NewInvalidKeyError(1), err)
+}
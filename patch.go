@@ -0,0 +1,205 @@
+package accessor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ApplyPatch applies a RFC 6902 JSON Patch to the object.
+// The patch is applied atomically, the object is untouched when any operation failed.
+// The length of a root SliceAccessor cannot be changed because the caller holds the slice,
+// so a patch which changes the number of its elements returns InvalidPatchError.
+// Wrap the slice in a map, or use the elements of a nested slice, to resize it.
+func ApplyPatch(acc Accessor, patch []byte) error {
+	var ops []patchOperation
+	err := json.Unmarshal(patch, &ops)
+	if err != nil {
+		return NewInvalidPatchError(err.Error())
+	}
+
+	doc := Clone(acc)
+	for _, op := range ops {
+		doc, err = op.apply(doc)
+		if err != nil {
+			return err
+		}
+	}
+
+	return replaceRoot(acc, doc)
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path,omitempty"`
+	From  *string         `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func (op patchOperation) apply(doc Accessor) (Accessor, error) {
+	path, err := op.pointer("path", op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add":
+		value, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	case "remove":
+		return removeValue(doc, path)
+	case "replace":
+		value, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+		_, err = doc.Get(path)
+		if err != nil {
+			return nil, err
+		}
+		return replaceValue(doc, path, value)
+	case "move":
+		from, err := op.pointer("from", op.From)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(path.JSONPointer(), from.JSONPointer()+"/") {
+			return nil, NewInvalidPatchError(fmt.Sprintf("cannot move %q into its child %q", from.JSONPointer(), path.JSONPointer()))
+		}
+		value, err := doc.Get(from)
+		if err != nil {
+			return nil, err
+		}
+		doc, err = removeValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	case "copy":
+		from, err := op.pointer("from", op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := doc.Get(from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, Clone(value))
+	case "test":
+		value, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+		actual, err := doc.Get(path)
+		if err != nil {
+			return nil, err
+		}
+		expected, err := NewAccessor(value)
+		if err != nil {
+			return nil, err
+		}
+		if !Equal(expected, actual, IgnoreNumericTypes()) {
+			return nil, NewTestFailedError(path, value, actual.Unwrap())
+		}
+		return doc, nil
+	default:
+		return nil, NewInvalidPatchError(fmt.Sprintf("unknown operation %q", op.Op))
+	}
+}
+
+func (op patchOperation) pointer(name string, pointer *string) (Path, error) {
+	if pointer == nil {
+		return nil, NewInvalidPatchError(fmt.Sprintf("%q operation requires %q", op.Op, name))
+	}
+	return ParseJSONPointer(*pointer)
+}
+
+func (op patchOperation) value() (interface{}, error) {
+	if op.Value == nil {
+		return nil, NewInvalidPatchError(fmt.Sprintf("%q operation requires %q", op.Op, "value"))
+	}
+
+	var value interface{}
+	err := json.Unmarshal(op.Value, &value)
+	if err != nil {
+		return nil, NewInvalidPatchError(err.Error())
+	}
+	return value, nil
+}
+
+// addValue adds the value as a RFC 6902 "add" operation.
+// The value is inserted when the parent is a slice, otherwise set.
+func addValue(doc Accessor, path Path, value interface{}) (Accessor, error) {
+	if path == thePhantomPath {
+		return NewAccessor(value)
+	}
+
+	parentPath, _ := splitPath(path)
+	parent, err := doc.Get(parentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := baseOf(parent).(SliceAccessor); ok {
+		if i, ok := doc.(inserter); ok {
+			return i.insert(path, value)
+		}
+		return nil, NewInvalidPatchError(fmt.Sprintf("cannot insert into %T", doc))
+	}
+
+	return setCreateTo(doc, path, value)
+}
+
+func removeValue(doc Accessor, path Path) (Accessor, error) {
+	if path == thePhantomPath {
+		return nil, NewInvalidPatchError("cannot remove the whole document")
+	}
+
+	return deleteFrom(doc, path)
+}
+
+func replaceValue(doc Accessor, path Path, value interface{}) (Accessor, error) {
+	if path == thePhantomPath {
+		return NewAccessor(value)
+	}
+
+	return setTo(doc, path, value)
+}
+
+// replaceRoot replaces the content of dst with src,
+// since the caller holds dst and cannot receive a new Accessor.
+func replaceRoot(dst, src Accessor) error {
+	if d, ok := dst.(*OrderedMapAccessor); ok {
+		if s, ok := src.(*OrderedMapAccessor); ok {
+			d.Map, d.Keys = s.Map, s.Keys
+			return nil
+		}
+	}
+
+	switch d := baseOf(dst).(type) {
+	case MapAccessor:
+		s, ok := baseOf(src).(MapAccessor)
+		if !ok {
+			return NewInvalidPatchError(fmt.Sprintf("cannot replace %T with %T", dst, src))
+		}
+		for k := range d {
+			delete(d, k)
+		}
+		for k, v := range s {
+			d[k] = v
+		}
+		return nil
+	case SliceAccessor:
+		s, ok := baseOf(src).(SliceAccessor)
+		if !ok || len(s) != len(d) {
+			return NewInvalidPatchError("cannot resize the root slice")
+		}
+		copy(d, s)
+		return nil
+	default:
+		return dst.Set(thePhantomPath, src.Unwrap())
+	}
+}
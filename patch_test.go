@@ -0,0 +1,297 @@
+package accessor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPatch(t *testing.T) {
+	type Input struct {
+		Document string
+		Patch    string
+	}
+	type Expect struct {
+		Document string
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "add",
+			Input: Input{
+				Document: `{"a": {"b": 1}, "c": [1, 2]}`,
+				Patch: `[
+					{"op": "add", "path": "/a/x", "value": {"y": null}},
+					{"op": "add", "path": "/c/1", "value": 3},
+					{"op": "add", "path": "/c/-", "value": 4},
+					{"op": "add", "path": "/n", "value": null}
+				]`,
+			},
+			Expect: Expect{
+				Document: `{"a": {"b": 1, "x": {"y": null}}, "c": [1, 3, 2, 4], "n": null}`,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "remove",
+			Input: Input{
+				Document: `{"a": {"b": 1}, "c": [1, 2]}`,
+				Patch: `[
+					{"op": "remove", "path": "/a/b"},
+					{"op": "remove", "path": "/c/0"}
+				]`,
+			},
+			Expect: Expect{
+				Document: `{"a": {}, "c": [2]}`,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "replace",
+			Input: Input{
+				Document: `{"a": {"b": 1}, "c": [1, 2]}`,
+				Patch: `[
+					{"op": "replace", "path": "/a", "value": "x"},
+					{"op": "replace", "path": "/c/1", "value": [3]}
+				]`,
+			},
+			Expect: Expect{
+				Document: `{"a": "x", "c": [1, [3]]}`,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "move and copy",
+			Input: Input{
+				Document: `{"a": {"b": 1}, "c": [1, 2]}`,
+				Patch: `[
+					{"op": "move", "from": "/a/b", "path": "/c/0"},
+					{"op": "copy", "from": "/c", "path": "/a/d"}
+				]`,
+			},
+			Expect: Expect{
+				Document: `{"a": {"d": [1, 1, 2]}, "c": [1, 1, 2]}`,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "test",
+			Input: Input{
+				Document: `{"a": {"b": 1}, "c": [1, 2]}`,
+				Patch: `[
+					{"op": "test", "path": "/a", "value": {"b": 1}},
+					{"op": "test", "path": "/c/1", "value": 2}
+				]`,
+			},
+			Expect: Expect{
+				Document: `{"a": {"b": 1}, "c": [1, 2]}`,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "replace whole document",
+			Input: Input{
+				Document: `{"a": 1}`,
+				Patch: `[
+					{"op": "replace", "path": "", "value": {"b": 2}}
+				]`,
+			},
+			Expect: Expect{
+				Document: `{"b": 2}`,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "test failed",
+			Input: Input{
+				Document: `{"a": 1, "b": 2}`,
+				Patch: `[
+					{"op": "remove", "path": "/b"},
+					{"op": "test", "path": "/a", "value": 2}
+				]`,
+			},
+			Expect: Expect{
+				Document: `{"a": 1, "b": 2}`,
+				Err:      NewTestFailedError(newPath("a"), float64(2), float64(1)),
+			},
+		},
+		{
+			Title: "path error",
+			Input: Input{
+				Document: `{"a": 1, "b": 2}`,
+				Patch: `[
+					{"op": "remove", "path": "/b"},
+					{"op": "add", "path": "/x/y", "value": 1}
+				]`,
+			},
+			Expect: Expect{
+				Document: `{"a": 1, "b": 2}`,
+				Err:      NewNoSuchPathError("no such key", "x"),
+			},
+		},
+		{
+			Title: "move into child",
+			Input: Input{
+				Document: `{"a": {"b": 1}}`,
+				Patch: `[
+					{"op": "move", "from": "/a", "path": "/a/b"}
+				]`,
+			},
+			Expect: Expect{
+				Document: `{"a": {"b": 1}}`,
+				Err:      NewInvalidPatchError(`cannot move "/a" into its child "/a/b"`),
+			},
+		},
+		{
+			Title: "missing value",
+			Input: Input{
+				Document: `{"a": 1}`,
+				Patch: `[
+					{"op": "add", "path": "/b"}
+				]`,
+			},
+			Expect: Expect{
+				Document: `{"a": 1}`,
+				Err:      NewInvalidPatchError(`"add" operation requires "value"`),
+			},
+		},
+		{
+			Title: "unknown operation",
+			Input: Input{
+				Document: `{"a": 1}`,
+				Patch: `[
+					{"op": "foo", "path": "/a"}
+				]`,
+			},
+			Expect: Expect{
+				Document: `{"a": 1}`,
+				Err:      NewInvalidPatchError(`unknown operation "foo"`),
+			},
+		},
+		{
+			Title: "replace in root slice",
+			Input: Input{
+				Document: `[1, 2]`,
+				Patch: `[
+					{"op": "replace", "path": "/0", "value": 0},
+					{"op": "move", "from": "/0", "path": "/1"}
+				]`,
+			},
+			Expect: Expect{
+				Document: `[2, 0]`,
+				Err:      nil,
+			},
+		},
+		{
+			Title: "resize root slice",
+			Input: Input{
+				Document: `[1, 2]`,
+				Patch: `[
+					{"op": "add", "path": "/0", "value": 0}
+				]`,
+			},
+			Expect: Expect{
+				Document: `[1, 2]`,
+				Err:      NewInvalidPatchError("cannot resize the root slice"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var doc interface{}
+			err := json.Unmarshal([]byte(testCase.Input.Document), &doc)
+			assert.Nil(err)
+			acc, err := NewAccessor(doc)
+			assert.Nil(err)
+
+			var expect interface{}
+			err = json.Unmarshal([]byte(testCase.Expect.Document), &expect)
+			assert.Nil(err)
+
+			err = ApplyPatch(acc, []byte(testCase.Input.Patch))
+
+			assert.Equal(expect, acc.Unwrap())
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+}
+
+func TestApplyPatch_Struct(t *testing.T) {
+	type Input struct {
+		Patch string
+	}
+	type Expect struct {
+		Config testConfig
+		Err    error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "test only",
+			Input: Input{
+				Patch: `[
+					{"op": "test", "path": "/name", "value": "me"}
+				]`,
+			},
+			Expect: Expect{
+				Config: testConfig{Name: "me", Ports: []int{80}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "replace and add",
+			Input: Input{
+				Patch: `[
+					{"op": "replace", "path": "/name", "value": "you"},
+					{"op": "add", "path": "/ports/-", "value": 443}
+				]`,
+			},
+			Expect: Expect{
+				Config: testConfig{Name: "you", Ports: []int{80, 443}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "failed",
+			Input: Input{
+				Patch: `[
+					{"op": "replace", "path": "/name", "value": "you"},
+					{"op": "test", "path": "/name", "value": "me"}
+				]`,
+			},
+			Expect: Expect{
+				Config: testConfig{Name: "me", Ports: []int{80}},
+				Err:    NewTestFailedError(newPath("name"), "me", "you"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			config := testConfig{Name: "me", Ports: []int{80}}
+			acc, err := NewAccessor(&config)
+			assert.Nil(err)
+
+			err = ApplyPatch(acc, []byte(testCase.Input.Patch))
+			assert.Equal(testCase.Expect.Err, err)
+			assert.Equal(testCase.Expect.Config, config)
+		})
+	}
+}
@@ -18,6 +18,9 @@ type Path interface {
 
 	// PushKey add a key to the head of the sequence.
 	PushKey(key string) Path
+
+	// JSONPointer returns the path formatted as a RFC 6901 JSON Pointer.
+	JSONPointer() string
 }
 
 type basicPath struct {
@@ -54,6 +57,16 @@ func (p *basicPath) String() string {
 	return buf.String()
 }
 
+func (p *basicPath) JSONPointer() string {
+	buf := &bytes.Buffer{}
+	var tail Path = p
+	for ok := true; ok; tail, ok = tail.SubPath() {
+		buf.WriteRune('/')
+		buf.WriteString(jsonPointerEscaper.Replace(tail.Key()))
+	}
+	return buf.String()
+}
+
 var thePhantomPath Path = phantomPath{}
 
 type phantomPath struct{}
@@ -77,6 +90,10 @@ func (p phantomPath) String() string {
 	return "???"
 }
 
+func (p phantomPath) JSONPointer() string {
+	return ""
+}
+
 // ParsePath creates a Path from a slash(/)-separeted-keys.
 func ParsePath(path string) (Path, error) {
 	keys := strings.Split(strings.Trim(path, "/ "), "/")
@@ -84,6 +101,45 @@ func ParsePath(path string) (Path, error) {
 	return NewPath(keys)
 }
 
+var (
+	jsonPointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// ParseJSONPointer creates a Path from a RFC 6901 JSON Pointer.
+// An empty string points the whole document, and an empty key is allowed.
+func ParseJSONPointer(pointer string) (Path, error) {
+	if pointer == "" {
+		return thePhantomPath, nil
+	}
+	if pointer[0] != '/' {
+		return nil, NewInvalidPathError("json pointer must start with /")
+	}
+
+	keys := strings.Split(pointer[1:], "/")
+	last := len(keys) - 1
+	p := thePhantomPath
+	for i := last; i >= 0; i-- {
+		if !isValidJSONPointerKey(keys[i]) {
+			return nil, NewInvalidPathError("invalid escape sequence found")
+		}
+		p = p.PushKey(jsonPointerUnescaper.Replace(keys[i]))
+	}
+	return p, nil
+}
+
+func isValidJSONPointerKey(key string) bool {
+	for i := 0; i < len(key); i++ {
+		if key[i] != '~' {
+			continue
+		}
+		if i+1 >= len(key) || (key[i+1] != '0' && key[i+1] != '1') {
+			return false
+		}
+	}
+	return true
+}
+
 // NewPath creates a Path from keys.
 func NewPath(keys []string) (Path, error) {
 	if len(keys) == 0 {
@@ -108,3 +164,39 @@ func newPath(keys ...string) Path {
 	}
 	return p
 }
+
+// pathFromKeys creates a Path from keys without validation.
+// The path of no keys is the phantom path.
+func pathFromKeys(keys []string) Path {
+	p := thePhantomPath
+	for i := len(keys) - 1; i >= 0; i-- {
+		p = p.PushKey(keys[i])
+	}
+	return p
+}
+
+// splitPath splits the path into the parent path and the last key.
+// The parent of a single key path is the phantom path.
+func splitPath(path Path) (Path, string) {
+	var keys []string
+	for tail, ok := path, true; ok; tail, ok = tail.SubPath() {
+		keys = append(keys, tail.Key())
+	}
+
+	return pathFromKeys(keys[:len(keys)-1]), keys[len(keys)-1]
+}
+
+// joinPath creates a Path which the keys of prefix are followed by the keys of path.
+func joinPath(prefix, path Path) Path {
+	if prefix == thePhantomPath {
+		return path
+	}
+	var keys []string
+	for tail, ok := prefix, true; ok; tail, ok = tail.SubPath() {
+		keys = append(keys, tail.Key())
+	}
+	for i := len(keys) - 1; i >= 0; i-- {
+		path = path.PushKey(keys[i])
+	}
+	return path
+}
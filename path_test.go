@@ -142,3 +142,113 @@ func TestNewPath(t *testing.T) {
 		})
 	}
 }
+
+func TestParseJSONPointer(t *testing.T) {
+	type Input struct {
+		Pointer string
+	}
+	type Expect struct {
+		Path Path
+		Err  error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "basic",
+			Input: Input{
+				Pointer: "/a/b/0",
+			},
+			Expect: Expect{
+				Path: &basicPath{"a", &basicPath{"b", &basicPath{"0", nil}}},
+				Err:  nil,
+			},
+		},
+		{
+			Title: "whole document",
+			Input: Input{
+				Pointer: "",
+			},
+			Expect: Expect{
+				Path: thePhantomPath,
+				Err:  nil,
+			},
+		},
+		{
+			Title: "empty key",
+			Input: Input{
+				Pointer: "/",
+			},
+			Expect: Expect{
+				Path: &basicPath{"", nil},
+				Err:  nil,
+			},
+		},
+		{
+			Title: "escaped",
+			Input: Input{
+				Pointer: "/a~1b/m~0n/~01",
+			},
+			Expect: Expect{
+				Path: &basicPath{"a/b", &basicPath{"m~n", &basicPath{"~1", nil}}},
+				Err:  nil,
+			},
+		},
+		{
+			Title: "no leading slash",
+			Input: Input{
+				Pointer: "a/b",
+			},
+			Expect: Expect{
+				Path: nil,
+				Err:  NewInvalidPathError("json pointer must start with /"),
+			},
+		},
+		{
+			Title: "invalid escape",
+			Input: Input{
+				Pointer: "/a~2",
+			},
+			Expect: Expect{
+				Path: nil,
+				Err:  NewInvalidPathError("invalid escape sequence found"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			path, err := ParseJSONPointer(testCase.Input.Pointer)
+
+			assert.Equal(testCase.Expect.Path, path)
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+}
+
+func TestPath_JSONPointer(t *testing.T) {
+	pointers := []string{
+		"",
+		"/",
+		"/a/b/0",
+		"/a~1b/m~0n/~01",
+		"//a//",
+	}
+
+	for _, pointer := range pointers {
+		t.Run(pointer, func(t *testing.T) {
+			assert := assert.New(t)
+
+			path, err := ParseJSONPointer(pointer)
+			assert.Nil(err)
+
+			assert.Equal(pointer, path.JSONPointer())
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package accessor
+
+import (
+	"reflect"
+)
+
+// Match is a object found by Query.
+type Match struct {
+	Path     Path
+	Accessor Accessor
+}
+
+// Query finds all objects matching the pattern.
+// The key "*" in the pattern matches any key of a map and any index of a slice,
+// and the key "**" matches zero or more keys recursively.
+// Other keys match exactly, and missing keys are not an error but no match.
+func Query(acc Accessor, pattern Path) ([]Match, error) {
+	var matches []Match
+	err := query(acc, pattern, nil, &matches)
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+func query(acc Accessor, pattern Path, keys []string, matches *[]Match) error {
+	if pattern == thePhantomPath {
+		*matches = append(*matches, Match{pathFromKeys(keys), acc})
+		return nil
+	}
+
+	rest, ok := pattern.SubPath()
+	if !ok {
+		rest = thePhantomPath
+	}
+
+	switch pattern.Key() {
+	case "*":
+		return eachChild(acc, func(key string, child Accessor) error {
+			return query(child, rest, appendKey(keys, key), matches)
+		})
+	case "**":
+		// Consecutive "**" match the same keys as one, and would duplicate the matches.
+		for rest != thePhantomPath && rest.Key() == "**" {
+			if rest, ok = rest.SubPath(); !ok {
+				rest = thePhantomPath
+			}
+		}
+		err := query(acc, rest, keys, matches)
+		if err != nil {
+			return err
+		}
+		return eachChild(acc, func(key string, child Accessor) error {
+			return query(child, pattern, appendKey(keys, key), matches)
+		})
+	default:
+		child, err := acc.Get(thePhantomPath.PushKey(pattern.Key()))
+		if err != nil {
+			if _, ok := err.(*NoSuchPathError); ok {
+				return nil
+			}
+			return err
+		}
+		return query(child, rest, appendKey(keys, pattern.Key()), matches)
+	}
+}
+
+// appendKey appends the key without sharing the backing array of keys.
+func appendKey(keys []string, key string) []string {
+	return append(keys[:len(keys):len(keys)], key)
+}
+
+// eachChild calls f for each child of a map in key order or a slice in index order.
+// The children of a struct or a LiveAccessor are enumerated as well.
+func eachChild(acc Accessor, f func(key string, child Accessor) error) error {
+	s, keys, err := sortedListOf(acc)
+	if err != nil || s == shapeValue {
+		return err
+	}
+	for _, k := range keys {
+		child, err := childOf(acc, k)
+		if err != nil {
+			return err
+		}
+		err = f(k, child)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSlice reports whether the object is a slice, which is not addressed by a name.
+func isSlice(acc Accessor) bool {
+	switch a := baseOf(acc).(type) {
+	case SliceAccessor:
+		return true
+	case *LiveAccessor:
+		return a.value.Kind() == reflect.Slice || a.value.Kind() == reflect.Array
+	default:
+		return false
+	}
+}
@@ -0,0 +1,157 @@
+package accessor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	type Input struct {
+		Accessor Accessor
+		Pattern  string
+	}
+	type Expect struct {
+		Paths []string
+		Err   error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	acc := MapAccessor(map[string]Accessor{
+		"name": &ValueAccessor{"me"},
+		"friends": SliceAccessor([]Accessor{
+			MapAccessor(map[string]Accessor{
+				"name": &ValueAccessor{"hello"},
+			}),
+			MapAccessor(map[string]Accessor{
+				"name": &ValueAccessor{"world"},
+				"pet": MapAccessor(map[string]Accessor{
+					"name": &ValueAccessor{"dog"},
+				}),
+			}),
+		}),
+	})
+
+	table := []Test{
+		{
+			Title: "exact",
+			Input: Input{
+				Accessor: acc,
+				Pattern:  "/friends/1/name",
+			},
+			Expect: Expect{
+				Paths: []string{"/friends/1/name"},
+				Err:   nil,
+			},
+		},
+		{
+			Title: "wildcard",
+			Input: Input{
+				Accessor: acc,
+				Pattern:  "/friends/*/name",
+			},
+			Expect: Expect{
+				Paths: []string{"/friends/0/name", "/friends/1/name"},
+				Err:   nil,
+			},
+		},
+		{
+			Title: "recursive",
+			Input: Input{
+				Accessor: acc,
+				Pattern:  "/**/name",
+			},
+			Expect: Expect{
+				Paths: []string{"/name", "/friends/0/name", "/friends/1/name", "/friends/1/pet/name"},
+				Err:   nil,
+			},
+		},
+		{
+			Title: "consecutive recursive",
+			Input: Input{
+				Accessor: acc,
+				Pattern:  "/friends/1/**/**/name",
+			},
+			Expect: Expect{
+				Paths: []string{"/friends/1/name", "/friends/1/pet/name"},
+				Err:   nil,
+			},
+		},
+		{
+			Title: "trailing consecutive recursive",
+			Input: Input{
+				Accessor: acc,
+				Pattern:  "/friends/1/pet/**/**",
+			},
+			Expect: Expect{
+				Paths: []string{"/friends/1/pet", "/friends/1/pet/name"},
+				Err:   nil,
+			},
+		},
+		{
+			Title: "no match",
+			Input: Input{
+				Accessor: acc,
+				Pattern:  "/friends/*/age",
+			},
+			Expect: Expect{
+				Paths: nil,
+				Err:   nil,
+			},
+		},
+		{
+			Title: "struct wildcard",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"s": mustAccessor(t, &testServer{"localhost", 80}),
+				}),
+				Pattern: "/s/*",
+			},
+			Expect: Expect{
+				Paths: []string{"/s/host", "/s/port"},
+				Err:   nil,
+			},
+		},
+		{
+			Title: "error",
+			Input: Input{
+				Accessor: MapAccessor(map[string]Accessor{
+					"dummy": DummyAccessor{1},
+				}),
+				Pattern: "/dummy/a",
+			},
+			Expect: Expect{
+				Paths: nil,
+				Err:   fmt.Errorf("this is dummy accessor: 1"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			pattern, err := ParseJSONPointer(testCase.Input.Pattern)
+			assert.Nil(err)
+
+			acc := testCase.Input.Accessor
+			matches, err := Query(acc, pattern)
+
+			var paths []string
+			for _, m := range matches {
+				paths = append(paths, m.Path.JSONPointer())
+
+				found, err := acc.Get(m.Path)
+				assert.Nil(err)
+				assert.Equal(found, m.Accessor)
+			}
+			assert.Equal(testCase.Expect.Paths, paths)
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+}
@@ -5,44 +5,175 @@ import (
 )
 
 // SliceAccessor is the Accessor for a slice.
+//
+// The slice cannot grow or shrink itself, so appending, inserting and deleting
+// an element are done through the parent object, which stores the resized slice.
 type SliceAccessor []Accessor
 
 // Get implements Accessor.
 func (a SliceAccessor) Get(path Path) (Accessor, error) {
-	i, err := strconv.Atoi(path.Key())
-	if err != nil {
-		return nil, NewNoSuchPathError("not a number", path.Key())
+	if path == thePhantomPath {
+		return a, nil
 	}
 
-	if i < 0 || i >= len(a) {
-		return nil, NewNoSuchPathError("index out of range", path.Key())
+	i, err := a.index(path.Key(), false)
+	if err != nil {
+		return nil, err
 	}
 
 	return getFromChild(a[i], path)
 }
 
 // Set implements Accessor.
+// The key "-" appends a new element to the end of the slice.
 func (a SliceAccessor) Set(path Path, value interface{}) error {
-	i, err := strconv.Atoi(path.Key())
+	if _, ok := path.SubPath(); !ok && path.Key() == "-" {
+		return NewNoSuchPathError("cannot append an element to the root slice", path.Key())
+	}
+	_, err := a.set(path, value)
+	return err
+}
+
+func (a SliceAccessor) set(path Path, value interface{}) (Accessor, error) {
+	sub, ok := path.SubPath()
+	if path.Key() == "-" && !ok {
+		acc, err := NewAccessor(value)
+		if err != nil {
+			return nil, err
+		}
+		return append(a, acc), nil
+	}
+
+	i, err := a.index(path.Key(), false)
 	if err != nil {
-		return NewNoSuchPathError("not a number", path.Key())
+		return nil, err
+	}
+
+	if !ok {
+		acc, err := NewAccessor(value)
+		if err != nil {
+			return nil, err
+		}
+		a[i] = acc
+		return a, nil
 	}
 
-	if i < 0 || i >= len(a) {
-		return NewNoSuchPathError("index out of range", path.Key())
+	child, err := setToChild(a[i], value, path.Key(), sub)
+	if err != nil {
+		return nil, err
+	}
+	a[i] = child
+	return a, nil
+}
+
+// SetCreate set a object into specific path like Set,
+// but creates missing objects in the path instead of returning NoSuchPathError.
+// The index equal to the length of the slice or "-" appends a new element.
+func (a SliceAccessor) SetCreate(path Path, value interface{}) error {
+	if _, ok := path.SubPath(); !ok && (path.Key() == "-" || path.Key() == strconv.Itoa(len(a))) {
+		return NewNoSuchPathError("cannot append an element to the root slice", path.Key())
+	}
+	_, err := a.setCreate(path, value)
+	return err
+}
+
+func (a SliceAccessor) setCreate(path Path, value interface{}) (Accessor, error) {
+	i, err := a.index(path.Key(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var child Accessor
+	if sub, ok := path.SubPath(); ok {
+		if i < len(a) {
+			child = a[i]
+		} else {
+			child = newContainer(sub.Key())
+		}
+		child, err = setCreateToChild(child, value, path.Key(), sub)
+	} else {
+		child, err = NewAccessor(value)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if i == len(a) {
+		return append(a, child), nil
+	}
+	a[i] = child
+	return a, nil
+}
+
+// Insert inserts a object into specific path and shifts the following elements.
+// The index equal to the length of the slice or "-" appends a new element.
+// NoSuchPathError is returned when the path is invalid.
+func (a SliceAccessor) Insert(path Path, value interface{}) error {
+	if _, ok := path.SubPath(); !ok {
+		return NewNoSuchPathError("cannot insert an element into the root slice", path.Key())
+	}
+	_, err := a.insert(path, value)
+	return err
+}
+
+func (a SliceAccessor) insert(path Path, value interface{}) (Accessor, error) {
+	i, err := a.index(path.Key(), true)
+	if err != nil {
+		return nil, err
 	}
 
 	sub, ok := path.SubPath()
 	if !ok {
 		acc, err := NewAccessor(value)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		a[i] = acc
-		return nil
+		result := make(SliceAccessor, 0, len(a)+1)
+		result = append(result, a[:i]...)
+		result = append(result, acc)
+		return append(result, a[i:]...), nil
+	}
+
+	if i == len(a) {
+		return nil, NewNoSuchPathError("index out of range", path.Key())
 	}
 
-	return setToChild(a[i], value, path.Key(), sub)
+	child, err := insertToChild(a[i], value, path.Key(), sub)
+	if err != nil {
+		return nil, err
+	}
+	a[i] = child
+	return a, nil
+}
+
+// Delete implements Accessor.
+func (a SliceAccessor) Delete(path Path) error {
+	if _, ok := path.SubPath(); !ok {
+		return NewNoSuchPathError("cannot delete an element from the root slice", path.Key())
+	}
+	_, err := a.delete(path)
+	return err
+}
+
+func (a SliceAccessor) delete(path Path) (Accessor, error) {
+	i, err := a.index(path.Key(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, ok := path.SubPath()
+	if !ok {
+		result := make(SliceAccessor, 0, len(a)-1)
+		result = append(result, a[:i]...)
+		return append(result, a[i+1:]...), nil
+	}
+
+	child, err := deleteFromChild(a[i], path.Key(), sub)
+	if err != nil {
+		return nil, err
+	}
+	a[i] = child
+	return a, nil
 }
 
 // Unwrap implements Accessor.
@@ -64,3 +195,52 @@ func (a SliceAccessor) Foreach(f func(path Path, value interface{}) error) error
 	}
 	return nil
 }
+
+// index converts the key into the index of the slice.
+// "-" means the end of the slice, and the end is valid only when end is true.
+func (a SliceAccessor) index(key string, end bool) (int, error) {
+	return sliceIndex(key, len(a), end)
+}
+
+func sliceIndex(key string, length int, end bool) (int, error) {
+	if key == "-" {
+		if !end {
+			return 0, NewNoSuchPathError("index out of range", key)
+		}
+		return length, nil
+	}
+
+	i, err := strconv.Atoi(key)
+	if err != nil {
+		return 0, NewNoSuchPathError("not a number", key)
+	}
+
+	limit := length
+	if end {
+		limit++
+	}
+	if i < 0 || i >= limit {
+		return 0, NewNoSuchPathError("index out of range", key)
+	}
+	return i, nil
+}
+
+func (a SliceAccessor) list() (shape, []string, error) {
+	return shapeSlice, indexKeys(len(a)), nil
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (a SliceAccessor) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// MarshalYAML implements gopkg.in/yaml.v2.Marshaler.
+func (a SliceAccessor) MarshalYAML() (interface{}, error) {
+	return marshalYAML(a)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the JSON encoding.
+func (a SliceAccessor) MarshalText() ([]byte, error) {
+	return marshalJSON(a)
+}
@@ -167,6 +167,43 @@ func TestSliceAccessor_Set(t *testing.T) {
 				Err: nil,
 			},
 		},
+		{
+			Title: "append nested",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+					}),
+				}),
+				Path:  "0/-",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+						DummyAccessor{2},
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "append to root slice",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Path:  "-",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Err: NewNoSuchPathError("cannot append an element to the root slice", "-"),
+			},
+		},
 		{
 			Title: "not a number",
 			Input: Input{
@@ -240,6 +277,342 @@ func TestSliceAccessor_Set(t *testing.T) {
 	}
 }
 
+func TestSliceAccessor_SetCreate(t *testing.T) {
+	type Input struct {
+		Accessor Accessor
+		Path     string
+		BeSet    Accessor
+	}
+	type Expect struct {
+		Accessor Accessor
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "success",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Path:  "0",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{2},
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "create nested",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{}),
+				}),
+				Path:  "0/-/a",
+				BeSet: DummyAccessor{1},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						MapAccessor(map[string]Accessor{
+							"a": DummyAccessor{1},
+						}),
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "root slice",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Path:  "-",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Err: NewNoSuchPathError("cannot append an element to the root slice", "-"),
+			},
+		},
+		{
+			Title: "index out of range",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{}),
+				}),
+				Path:  "0/1",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{}),
+				}),
+				Err: NewNoSuchPathError("index out of range", "1", "0"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+			acc := testCase.Input.Accessor
+			err = acc.(SliceAccessor).SetCreate(path, testCase.Input.BeSet)
+
+			assert.Equal(testCase.Expect.Accessor, acc)
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+}
+
+func TestSliceAccessor_Insert(t *testing.T) {
+	type Input struct {
+		Accessor Accessor
+		Path     string
+		BeSet    Accessor
+	}
+	type Expect struct {
+		Accessor Accessor
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "success",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+						DummyAccessor{2},
+					}),
+				}),
+				Path:  "0/1",
+				BeSet: DummyAccessor{3},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+						DummyAccessor{3},
+						DummyAccessor{2},
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "append",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+					}),
+				}),
+				Path:  "0/-",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+						DummyAccessor{2},
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "root slice",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Path:  "0",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Err: NewNoSuchPathError("cannot insert an element into the root slice", "0"),
+			},
+		},
+		{
+			Title: "index out of range",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+					}),
+				}),
+				Path:  "0/2",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+					}),
+				}),
+				Err: NewNoSuchPathError("index out of range", "2", "0"),
+			},
+		},
+		{
+			Title: "not a slice",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Path:  "0/0",
+				BeSet: DummyAccessor{2},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Err: NewNoSuchPathError("cannot insert into accessor.DummyAccessor", "0", "0"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+			acc := testCase.Input.Accessor
+			err = acc.(SliceAccessor).Insert(path, testCase.Input.BeSet)
+
+			assert.Equal(testCase.Expect.Accessor, acc)
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+}
+
+func TestSliceAccessor_Delete(t *testing.T) {
+	type Input struct {
+		Accessor Accessor
+		Path     string
+	}
+	type Expect struct {
+		Accessor Accessor
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "success nested",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+						DummyAccessor{2},
+						DummyAccessor{3},
+					}),
+				}),
+				Path: "0/1",
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+						DummyAccessor{3},
+					}),
+				}),
+				Err: nil,
+			},
+		},
+		{
+			Title: "root slice",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Path: "0",
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Err: NewNoSuchPathError("cannot delete an element from the root slice", "0"),
+			},
+		},
+		{
+			Title: "not a number",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+					}),
+				}),
+				Path: "0/x",
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					SliceAccessor([]Accessor{
+						DummyAccessor{1},
+					}),
+				}),
+				Err: NewNoSuchPathError("not a number", "x", "0"),
+			},
+		},
+		{
+			Title: "index out of range",
+			Input: Input{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Path: "1/0",
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor([]Accessor{
+					DummyAccessor{1},
+				}),
+				Err: NewNoSuchPathError("index out of range", "1"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+			acc := testCase.Input.Accessor
+			err = acc.Delete(path)
+
+			assert.Equal(testCase.Expect.Accessor, acc)
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+}
+
 func TestSliceAccessor_Unwrap(t *testing.T) {
 	type Input struct {
 		Accessor Accessor
@@ -0,0 +1,366 @@
+package accessor
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// StructAccessor is the Accessor for a struct.
+// The exported fields are addressed by the name in the json, yaml, toml or mapstructure tag
+// in this order, or by the field name if no tag is given.
+// A field tagged with "-" is ignored, the fields of an embedded struct are promoted,
+// and an empty field tagged with omitempty is treated as missing by Get and Foreach.
+//
+// A struct given by a pointer is updated by Set, so the caller can see the change
+// without calling Unwrap.
+type StructAccessor struct {
+	value  reflect.Value
+	ptr    reflect.Value
+	fields []structField
+	opts   *options
+}
+
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+func newStructAccessor(value, ptr reflect.Value, o *options) *StructAccessor {
+	// The children are typed so that they can be written back into the fields.
+	fo := *o
+	fo.preserveTypes = true
+	return &StructAccessor{value, ptr, structFields(value.Type()), &fo}
+}
+
+// Get implements Accessor.
+func (a *StructAccessor) Get(path Path) (Accessor, error) {
+	if path == thePhantomPath {
+		return a, nil
+	}
+
+	f, fv, ok := a.field(path.Key())
+	if !ok || (f.omitEmpty && isEmptyValue(fv)) {
+		return nil, NewNoSuchPathError("no such key", path.Key())
+	}
+
+	child, err := newFieldAccessor(fv, a.opts)
+	if err != nil {
+		return nil, err
+	}
+	return getFromChild(child, path)
+}
+
+// Set implements Accessor.
+// TypeMismatchError is returned when the value cannot be converted into the type of the field.
+// The root path replaces the whole struct by a struct or a pointer to the struct of the same type.
+func (a *StructAccessor) Set(path Path, value interface{}) error {
+	if path == thePhantomPath {
+		return a.setRoot(value)
+	}
+
+	_, fv, ok := a.field(path.Key())
+	if !ok {
+		return NewNoSuchPathError("no such key", path.Key())
+	}
+
+	sub, ok := path.SubPath()
+	if !ok {
+		if acc, ok := value.(Accessor); ok {
+			value = acc.Unwrap()
+		}
+		rv, ok := convertValue(value, fv.Type())
+		if !ok {
+			return NewTypeMismatchError(value, fv.Type(), path)
+		}
+		fv.Set(rv)
+		return nil
+	}
+
+	child, err := newFieldAccessor(fv, a.opts)
+	if err != nil {
+		return err
+	}
+	child, err = setToChild(child, value, path.Key(), sub)
+	if err != nil {
+		return err
+	}
+	return writeBack(fv, child, path.Key())
+}
+
+// insert inserts the value into the slice in the field, since a field itself cannot be inserted.
+func (a *StructAccessor) insert(path Path, value interface{}) (Accessor, error) {
+	_, fv, ok := a.field(path.Key())
+	if !ok {
+		return nil, NewNoSuchPathError("no such key", path.Key())
+	}
+
+	sub, ok := path.SubPath()
+	if !ok {
+		return nil, NewNoSuchPathError("cannot insert a field into a struct", path.Key())
+	}
+
+	child, err := newFieldAccessor(fv, a.opts)
+	if err != nil {
+		return nil, err
+	}
+	child, err = insertToChild(child, value, path.Key(), sub)
+	if err != nil {
+		return nil, err
+	}
+	return a, writeBack(fv, child, path.Key())
+}
+
+func (a *StructAccessor) setRoot(value interface{}) error {
+	if acc, ok := value.(Accessor); ok {
+		value = acc.Unwrap()
+	}
+
+	t := a.value.Type()
+	rv, ok := convertValue(value, reflect.PtrTo(t))
+	if ok && !rv.IsNil() {
+		rv = rv.Elem()
+	} else {
+		rv, ok = convertValue(value, t)
+	}
+	if !ok || !a.value.CanSet() {
+		return NewTypeMismatchError(value, t, thePhantomPath)
+	}
+	a.value.Set(rv)
+	return nil
+}
+
+// Delete implements Accessor.
+// A field itself cannot be deleted, but a value in the field can be.
+func (a *StructAccessor) Delete(path Path) error {
+	_, fv, ok := a.field(path.Key())
+	if !ok {
+		return NewNoSuchPathError("no such key", path.Key())
+	}
+
+	sub, ok := path.SubPath()
+	if !ok {
+		return NewNoSuchPathError("cannot delete a field of a struct", path.Key())
+	}
+
+	child, err := newFieldAccessor(fv, a.opts)
+	if err != nil {
+		return err
+	}
+	child, err = deleteFromChild(child, path.Key(), sub)
+	if err != nil {
+		return err
+	}
+	return writeBack(fv, child, path.Key())
+}
+
+// Unwrap implements Accessor.
+// It returns the pointer if the struct was given by a pointer.
+func (a *StructAccessor) Unwrap() interface{} {
+	if a.ptr.IsValid() {
+		return a.ptr.Interface()
+	}
+	return a.value.Interface()
+}
+
+// Foreach implements Accessor.
+// The fields are enumerated in the order of the declaration.
+func (a *StructAccessor) Foreach(f func(path Path, value interface{}) error) error {
+	for _, sf := range a.fields {
+		fv, ok := fieldByIndex(a.value, sf.index)
+		if !ok || (sf.omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+
+		child, err := newFieldAccessor(fv, a.opts)
+		if err != nil {
+			return err
+		}
+		if err := foreach(child, sf.name, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *StructAccessor) list() (shape, []string, error) {
+	var keys []string
+	for _, sf := range a.fields {
+		fv, ok := fieldByIndex(a.value, sf.index)
+		if !ok || (sf.omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+		keys = append(keys, sf.name)
+	}
+	return shapeMap, keys, nil
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (a *StructAccessor) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// MarshalYAML implements gopkg.in/yaml.v2.Marshaler.
+func (a *StructAccessor) MarshalYAML() (interface{}, error) {
+	return marshalYAML(a)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the JSON encoding.
+func (a *StructAccessor) MarshalText() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+func (a *StructAccessor) field(key string) (structField, reflect.Value, bool) {
+	for _, f := range a.fields {
+		if f.name != key {
+			continue
+		}
+		fv, ok := fieldByIndex(a.value, f.index)
+		return f, fv, ok
+	}
+	return structField{}, reflect.Value{}, false
+}
+
+// newFieldAccessor creates the Accessor for the value of a field.
+// A struct field is accessed in place, and others are written back by writeBack.
+func newFieldAccessor(fv reflect.Value, o *options) (Accessor, error) {
+	if fv.Kind() == reflect.Struct && !isOpaque(fv.Type()) {
+		return newStructAccessor(fv, reflect.Value{}, o), nil
+	}
+	return newAccessor(fv.Interface(), o)
+}
+
+// writeBack stores the value of the child into the field.
+func writeBack(fv reflect.Value, child Accessor, key string) error {
+	v := child.Unwrap()
+	rv, ok := convertValue(v, fv.Type())
+	if !ok {
+		return NewTypeMismatchError(v, fv.Type(), thePhantomPath.PushKey(key))
+	}
+	fv.Set(rv)
+	return nil
+}
+
+// fieldByIndex is same as reflect.Value.FieldByIndex,
+// but returns false instead of panic when an embedded pointer is nil.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// structFields lists the fields of the struct type.
+// A field of the outer struct hides the promoted field with the same name.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	depth := map[string]int{}
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			name, opts := fieldTag(sf)
+			if name == "-" {
+				continue
+			}
+
+			idx := make([]int, len(index)+1)
+			copy(idx, index)
+			idx[len(index)] = i
+
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ((sf.Anonymous && name == "") || hasOption(opts, "inline") || hasOption(opts, "squash")) && ft.Kind() == reflect.Struct {
+				walk(ft, idx)
+				continue
+			}
+
+			if sf.PkgPath != "" {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+
+			if d, ok := depth[name]; ok {
+				if d <= len(idx) {
+					continue
+				}
+				for j := range fields {
+					if fields[j].name == name {
+						fields = append(fields[:j], fields[j+1:]...)
+						break
+					}
+				}
+			}
+			depth[name] = len(idx)
+			fields = append(fields, structField{name, idx, hasOption(opts, "omitempty")})
+		}
+	}
+	walk(t, nil)
+	return fields
+}
+
+// fieldTag returns the name and the options in the json, yaml, toml or mapstructure tag.
+func fieldTag(sf reflect.StructField) (string, []string) {
+	for _, key := range []string{"json", "yaml", "toml", "mapstructure"} {
+		if tag, ok := sf.Tag.Lookup(key); ok {
+			parts := strings.Split(tag, ",")
+			return parts[0], parts[1:]
+		}
+	}
+	return "", nil
+}
+
+func hasOption(opts []string, opt string) bool {
+	for _, o := range opts {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue reports whether the value is empty in terms of omitempty.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// isOpaque reports whether the struct type should be treated as a value,
+// such as time.Time which is marshaled by itself.
+func isOpaque(t reflect.Type) bool {
+	pt := reflect.PtrTo(t)
+	return t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+		pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType)
+}
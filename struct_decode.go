@@ -0,0 +1,324 @@
+package accessor
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	accessorType        = reflect.TypeOf((*Accessor)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// DecodeOption is an option for Decode.
+type DecodeOption func(*decoder)
+
+// WeaklyTyped converts the values between the types like mapstructure.
+// A string is parsed into a number or a bool, a number or a bool is formatted
+// into a string, a bool becomes 1 or 0 and a number becomes true unless it is 0,
+// and a single value becomes a slice of one element.
+func WeaklyTyped() DecodeOption {
+	return func(d *decoder) {
+		d.weak = true
+	}
+}
+
+// ErrorUnused reports the keys which match no field of a struct as NoSuchPathError.
+func ErrorUnused() DecodeOption {
+	return func(d *decoder) {
+		d.errorUnused = true
+	}
+}
+
+// Decode finds the object by the path and decodes it into the value pointed by out
+// with the same rules as GetAs.
+// Like encoding/json, the fields of a struct which are not in the object keep the current value,
+// so the defaults can be set before decoding. A map is updated by the keys of the object,
+// and a slice is replaced.
+// Unlike GetAs, it continues decoding after an error, and returns DecodeError
+// which has the errors of all invalid values with their full path.
+// The value pointed by out is not changed when an error is returned.
+func Decode(acc Accessor, path Path, out interface{}, opts ...DecodeOption) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("cannot decode into %T(%v): must be a non-nil pointer", out, out)
+	}
+
+	child, err := acc.Get(path)
+	if err != nil {
+		return err
+	}
+
+	d := &decoder{collect: true}
+	for _, opt := range opts {
+		opt(d)
+	}
+	v := reflect.New(rv.Elem().Type()).Elem()
+	v.Set((&cloneOptions{copyValue: copyValue}).copyReflect(rv.Elem()))
+	if err := d.decode(child, v, path); err != nil {
+		return err
+	}
+	if len(d.errs) > 0 {
+		return NewDecodeError(d.errs)
+	}
+	rv.Elem().Set(v)
+	return nil
+}
+
+type decoder struct {
+	weak        bool
+	errorUnused bool
+	collect     bool
+	errs        []error
+}
+
+// report returns the error, or records it to continue decoding if collect is set.
+func (d *decoder) report(err error) error {
+	if !d.collect {
+		return err
+	}
+	d.errs = append(d.errs, err)
+	return nil
+}
+
+func (d *decoder) mismatch(v interface{}, t reflect.Type, path Path) (reflect.Value, error) {
+	return reflect.Zero(t), d.report(NewTypeMismatchError(v, t, path))
+}
+
+// decode decodes the object at the path into out, which must be settable.
+// A nil pointer or map is allocated, and the others are updated in place.
+func (d *decoder) decode(acc Accessor, out reflect.Value, path Path) error {
+	t := out.Type()
+	if t == accessorType {
+		out.Set(reflect.ValueOf(acc))
+		return nil
+	}
+
+	s, keys, err := listOf(acc)
+	if err != nil {
+		return d.report(err)
+	}
+	if t.Kind() == reflect.Ptr && (s != shapeValue || acc.Unwrap() != nil) {
+		if out.IsNil() {
+			out.Set(reflect.New(t.Elem()))
+		}
+		return d.decode(acc, out.Elem(), path)
+	}
+	if s == shapeValue {
+		v, err := d.decodeScalar(acc.Unwrap(), t, path)
+		if err != nil {
+			return err
+		}
+		out.Set(v)
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if s != shapeMap {
+			break
+		}
+		fields := structFields(t)
+		for _, k := range keys {
+			f, ok := matchField(fields, k)
+			if !ok {
+				if d.errorUnused {
+					e := NewNoSuchPathError("no such field", k)
+					e.Path = path
+					if err := d.report(e); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			fv, ok := allocField(out, f.index)
+			if !ok {
+				e := NewNoSuchPathError("cannot set embedded pointer to unexported struct", k)
+				e.Path = path
+				if err := d.report(e); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.decodeChild(acc, k, fv, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if s != shapeMap {
+			break
+		}
+		if out.IsNil() {
+			out.Set(reflect.MakeMapWithSize(t, len(keys)))
+		}
+		for _, k := range keys {
+			mk, ok := convertKey(k, t.Key())
+			if !ok {
+				if err := d.report(NewTypeMismatchError(k, t.Key(), joinPath(path, thePhantomPath.PushKey(k)))); err != nil {
+					return err
+				}
+				continue
+			}
+			elem := reflect.New(t.Elem()).Elem()
+			if err := d.decodeChild(acc, k, elem, path); err != nil {
+				return err
+			}
+			out.SetMapIndex(mk, elem)
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		if s != shapeSlice {
+			break
+		}
+		result := out
+		if t.Kind() == reflect.Slice {
+			result = reflect.MakeSlice(t, len(keys), len(keys))
+		} else if t.Len() != len(keys) {
+			break
+		}
+		for i, k := range keys {
+			if err := d.decodeChild(acc, k, result.Index(i), path); err != nil {
+				return err
+			}
+		}
+		out.Set(result)
+		return nil
+	case reflect.Interface:
+		if rv, ok := convertValue(acc.Unwrap(), t); ok {
+			out.Set(rv)
+			return nil
+		}
+	}
+	_, err = d.mismatch(acc.Unwrap(), t, path)
+	return err
+}
+
+// decodeChild decodes the child of the key into out.
+func (d *decoder) decodeChild(acc Accessor, key string, out reflect.Value, path Path) error {
+	path = joinPath(path, thePhantomPath.PushKey(key))
+	child, err := acc.Get(thePhantomPath.PushKey(key))
+	if err != nil {
+		return d.report(err)
+	}
+	return d.decode(child, out, path)
+}
+
+// decodeScalar decodes a value which is not a map or a slice into the type t.
+func (d *decoder) decodeScalar(v interface{}, t reflect.Type, path Path) (reflect.Value, error) {
+	if s, ok := v.(string); ok {
+		switch {
+		case t == durationType:
+			dur, err := time.ParseDuration(s)
+			if err != nil {
+				return d.mismatch(v, t, path)
+			}
+			return reflect.ValueOf(dur), nil
+		case t.Kind() != reflect.String && reflect.PtrTo(t).Implements(textUnmarshalerType):
+			result := reflect.New(t)
+			if err := result.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+				return d.mismatch(v, t, path)
+			}
+			return result.Elem(), nil
+		}
+	}
+
+	if t.Kind() == reflect.Ptr && v != nil {
+		elem, err := d.decodeScalar(v, t.Elem(), path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result := reflect.New(t.Elem())
+		result.Elem().Set(elem)
+		return result, nil
+	}
+
+	if rv, ok := convertValue(v, t); ok {
+		return rv, nil
+	}
+	if d.weak {
+		if rv, ok := weakConvert(v, t); ok {
+			return rv, nil
+		}
+		if t.Kind() == reflect.Slice && v != nil {
+			elem, err := d.decodeScalar(v, t.Elem(), path)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result := reflect.MakeSlice(t, 1, 1)
+			result.Index(0).Set(elem)
+			return result, nil
+		}
+	}
+	return d.mismatch(v, t, path)
+}
+
+// weakConvert converts a string, a number or a bool into the type t.
+func weakConvert(v interface{}, t reflect.Type) (reflect.Value, bool) {
+	if v == nil {
+		return reflect.Value{}, false
+	}
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.String:
+		return convertKey(strings.TrimSpace(rv.String()), t)
+	case rv.Kind() == reflect.Bool:
+		switch {
+		case t.Kind() == reflect.String:
+			return convertKey(fmt.Sprint(v), t)
+		case isNumberKind(t.Kind()):
+			n := 0
+			if rv.Bool() {
+				n = 1
+			}
+			return convertValue(n, t)
+		}
+	case isNumberKind(rv.Kind()):
+		switch t.Kind() {
+		case reflect.String:
+			return convertKey(fmt.Sprint(v), t)
+		case reflect.Bool:
+			return reflect.ValueOf(!rv.IsZero()).Convert(t), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// matchField finds the field by the key,
+// or by case-insensitive match if no field has the exact name.
+func matchField(fields []structField, key string) (structField, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return structField{}, false
+}
+
+// allocField is same as reflect.Value.FieldByIndex,
+// but allocates a struct for a nil embedded pointer.
+// It returns false when the pointer cannot be set because the struct is unexported,
+// like encoding/json.
+func allocField(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
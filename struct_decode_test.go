@@ -0,0 +1,200 @@
+package accessor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCredential struct {
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
+}
+
+type testTLS struct {
+	CA string `yaml:"ca"`
+}
+
+type testDatabaseConfig struct {
+	testCredential `mapstructure:",squash"`
+	Host           string        `yaml:"host"`
+	Port           int           `yaml:"port"`
+	Timeout        time.Duration `yaml:"timeout"`
+	Replicas       []string      `yaml:"replicas"`
+	ReadOnly       bool          `yaml:"read_only"`
+	*testTLS
+}
+
+func TestDecode(t *testing.T) {
+	type Input struct {
+		Document string
+		Options  []DecodeOption
+	}
+	type Expect struct {
+		Config testDatabaseConfig
+		Err    error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "success",
+			Input: Input{
+				Document: `
+database:
+  user: admin
+  password: secret
+  host: localhost
+  port: 5432
+  timeout: 3s
+  replicas: [a, b]
+  read_only: true
+`,
+				Options: nil,
+			},
+			Expect: Expect{
+				Config: testDatabaseConfig{
+					testCredential: testCredential{"admin", "secret"},
+					Host:           "localhost",
+					Port:           5432,
+					Timeout:        3 * time.Second,
+					Replicas:       []string{"a", "b"},
+					ReadOnly:       true,
+				},
+				Err: nil,
+			},
+		},
+		{
+			Title: "weakly typed",
+			Input: Input{
+				Document: `
+database:
+  user: 1
+  port: "5432"
+  replicas: a
+  read_only: "1"
+`,
+				Options: []DecodeOption{WeaklyTyped()},
+			},
+			Expect: Expect{
+				Config: testDatabaseConfig{
+					testCredential: testCredential{User: "1"},
+					Port:           5432,
+					Replicas:       []string{"a"},
+					ReadOnly:       true,
+				},
+				Err: nil,
+			},
+		},
+		{
+			Title: "all errors",
+			Input: Input{
+				Document: `
+database:
+  port: "5432"
+  timeout: soon
+  replicas: [a, [b]]
+  unknown: 1
+`,
+				Options: []DecodeOption{ErrorUnused()},
+			},
+			Expect: Expect{
+				Config: testDatabaseConfig{},
+				Err: NewDecodeError([]error{
+					NewTypeMismatchError("5432", reflect.TypeOf(0), newPath("database", "port")),
+					NewTypeMismatchError([]interface{}{"b"}, reflect.TypeOf(""), newPath("database", "replicas", "1")),
+					NewTypeMismatchError("soon", durationType, newPath("database", "timeout")),
+					&NoSuchPathError{"no such field", "unknown", newPath("database")},
+				}),
+			},
+		},
+		{
+			Title: "unexported embedded pointer",
+			Input: Input{
+				Document: `
+database:
+  host: localhost
+  ca: secret
+`,
+				Options: nil,
+			},
+			Expect: Expect{
+				Config: testDatabaseConfig{},
+				Err: NewDecodeError([]error{
+					&NoSuchPathError{"cannot set embedded pointer to unexported struct", "ca", newPath("database")},
+				}),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			acc, err := FromYAML(strings.NewReader(testCase.Input.Document))
+			assert.Nil(err)
+
+			var config testDatabaseConfig
+			err = Decode(acc, newPath("database"), &config, testCase.Input.Options...)
+			assert.Equal(testCase.Expect.Err, err)
+			assert.Equal(testCase.Expect.Config, config)
+		})
+	}
+}
+
+func TestDecode_Defaults(t *testing.T) {
+	assert := assert.New(t)
+
+	acc, err := FromYAML(strings.NewReader(`
+database:
+  host: db
+  replicas: [a]
+`))
+	assert.Nil(err)
+
+	config := testDatabaseConfig{Port: 5432, Timeout: time.Second, Replicas: []string{"x", "y"}}
+	assert.Nil(Decode(acc, newPath("database"), &config))
+	assert.Equal(testDatabaseConfig{Host: "db", Port: 5432, Timeout: time.Second, Replicas: []string{"a"}}, config)
+
+	labels := map[string]string{"a": "x", "b": "y"}
+	assert.Nil(Decode(mustAccessor(t, map[string]interface{}{"b": "z"}), thePhantomPath, &labels))
+	assert.Equal(map[string]string{"a": "x", "b": "z"}, labels)
+
+	server := &testServer{"localhost", 80}
+	saved := server
+	assert.Nil(Decode(mustAccessor(t, map[string]interface{}{"port": 8080}), thePhantomPath, &server))
+	assert.Equal(&testServer{"localhost", 8080}, server)
+	assert.Equal(&testServer{"localhost", 80}, saved)
+
+	config = testDatabaseConfig{Port: 5432}
+	assert.NotNil(Decode(mustAccessor(t, map[string]interface{}{"host": "db", "port": "x"}), thePhantomPath, &config))
+	assert.Equal(testDatabaseConfig{Port: 5432}, config)
+}
+
+func TestDecode_Error(t *testing.T) {
+	assert := assert.New(t)
+
+	acc := mustAccessor(t, map[string]interface{}{"a": 1})
+
+	var n int
+	assert.Equal(NewNoSuchPathError("no such key", "b"), Decode(acc, newPath("b"), &n))
+	assert.NotNil(Decode(acc, newPath("a"), n))
+	assert.Nil(Decode(acc, newPath("a"), &n))
+	assert.Equal(1, n)
+
+	_, err := GetAs[testDatabaseConfig](mustAccessor(t, map[string]interface{}{"ca": "secret"}), thePhantomPath)
+	assert.Equal(&NoSuchPathError{"cannot set embedded pointer to unexported struct", "ca", thePhantomPath}, err)
+
+	err = NewDecodeError([]error{
+		NewTypeMismatchError("x", reflect.TypeOf(0), newPath("a")),
+		NewTypeMismatchError("y", reflect.TypeOf(0), newPath("b")),
+	})
+	assert.Equal("2 error(s) decoding: cannot use string(x) as int: at a; cannot use string(y) as int: at b", err.Error())
+}
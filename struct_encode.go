@@ -0,0 +1,104 @@
+package accessor
+
+import (
+	"encoding"
+	"reflect"
+)
+
+// Encode converts the value into a tree of MapAccessor, SliceAccessor and ValueAccessor,
+// so that a typed value can be merged with the documents by the same operations.
+// The fields of a struct become the keys of a MapAccessor with the same rules as StructAccessor,
+// and an empty field tagged with omitempty is omitted.
+// A value implementing encoding.TextMarshaler, such as time.Time, becomes the string.
+// The non-string keys of a map are stringified like StringifyKeys.
+// Unlike NewAccessor, the tree does not refer to the value.
+func Encode(v interface{}) (Accessor, error) {
+	return encodeValue(reflect.ValueOf(v))
+}
+
+func encodeValue(rv reflect.Value) (Accessor, error) {
+	if !rv.IsValid() {
+		return &ValueAccessor{nil}, nil
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if rv.IsNil() {
+			return &ValueAccessor{nil}, nil
+		}
+	}
+	if acc, ok := rv.Interface().(Accessor); ok {
+		return encodeValue(reflect.ValueOf(acc.Unwrap()))
+	}
+	if rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		return encodeValue(rv.Elem())
+	}
+
+	if m, ok := textMarshaler(rv); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return &ValueAccessor{string(text)}, nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		result := MapAccessor{}
+		for _, sf := range structFields(rv.Type()) {
+			fv, ok := fieldByIndex(rv, sf.index)
+			if !ok || (sf.omitEmpty && isEmptyValue(fv)) {
+				continue
+			}
+			child, err := encodeValue(fv)
+			if err != nil {
+				return nil, err
+			}
+			result[sf.name] = child
+		}
+		return result, nil
+	case reflect.Map:
+		result := MapAccessor{}
+		iter := rv.MapRange()
+		for iter.Next() {
+			key, ok := stringifyKey(iter.Key().Interface())
+			if !ok {
+				return nil, NewInvalidKeyError(iter.Key().Interface())
+			}
+			child, err := encodeValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			result[key] = child
+		}
+		return result, nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return &ValueAccessor{rv.Interface()}, nil
+		}
+		result := make(SliceAccessor, rv.Len())
+		for i := range result {
+			child, err := encodeValue(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = child
+		}
+		return result, nil
+	default:
+		return &ValueAccessor{rv.Interface()}, nil
+	}
+}
+
+// textMarshaler returns encoding.TextMarshaler of the value
+// including the one implemented by the pointer.
+func textMarshaler(rv reflect.Value) (encoding.TextMarshaler, bool) {
+	if rv.Type().Implements(textMarshalerType) {
+		return rv.Interface().(encoding.TextMarshaler), true
+	}
+	if !reflect.PtrTo(rv.Type()).Implements(textMarshalerType) {
+		return nil, false
+	}
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+	return ptr.Interface().(encoding.TextMarshaler), true
+}
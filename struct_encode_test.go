@@ -0,0 +1,138 @@
+package accessor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testFailingText struct{}
+
+func (testFailingText) MarshalText() ([]byte, error) {
+	return nil, errors.New("failed")
+}
+
+func TestEncode(t *testing.T) {
+	type Input struct {
+		Value interface{}
+	}
+	type Expect struct {
+		Accessor Accessor
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "struct",
+			Input: Input{
+				Value: testConfig{
+					testMeta: testMeta{1},
+					Name:     "app",
+					Server:   testServer{"localhost", 80},
+					Ports:    []int{80},
+					Secret:   "secret",
+					Created:  time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC),
+				},
+			},
+			Expect: Expect{
+				Accessor: MapAccessor{
+					"version": &ValueAccessor{1},
+					"name":    &ValueAccessor{"app"},
+					"server": MapAccessor{
+						"host": &ValueAccessor{"localhost"},
+						"port": &ValueAccessor{80},
+					},
+					"ports":   SliceAccessor{&ValueAccessor{80}},
+					"created": &ValueAccessor{"2018-01-02T03:04:05Z"},
+					"Plain":   &ValueAccessor{false},
+				},
+				Err: nil,
+			},
+		},
+		{
+			Title: "pointer and map",
+			Input: Input{
+				Value: &map[int]*testServer{1: {"a", 1}, 2: nil},
+			},
+			Expect: Expect{
+				Accessor: MapAccessor{
+					"1": MapAccessor{
+						"host": &ValueAccessor{"a"},
+						"port": &ValueAccessor{1},
+					},
+					"2": &ValueAccessor{nil},
+				},
+				Err: nil,
+			},
+		},
+		{
+			Title: "accessor",
+			Input: Input{
+				Value: []interface{}{MapAccessor{"a": &ValueAccessor{1}}, []byte("b"), []int(nil)},
+			},
+			Expect: Expect{
+				Accessor: SliceAccessor{
+					MapAccessor{"a": &ValueAccessor{1}},
+					&ValueAccessor{[]byte("b")},
+					&ValueAccessor{nil},
+				},
+				Err: nil,
+			},
+		},
+		{
+			Title: "invalid key",
+			Input: Input{
+				Value: map[[1]int]int{{1}: 1},
+			},
+			Expect: Expect{
+				Accessor: nil,
+				Err:      NewInvalidKeyError([1]int{1}),
+			},
+		},
+		{
+			Title: "marshal error",
+			Input: Input{
+				Value: map[string]interface{}{"a": testFailingText{}},
+			},
+			Expect: Expect{
+				Accessor: nil,
+				Err:      errors.New("failed"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			acc, err := Encode(testCase.Input.Value)
+			assert.Equal(testCase.Expect.Err, err)
+			assert.Equal(testCase.Expect.Accessor, acc)
+		})
+	}
+}
+
+func TestEncode_MergeDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	defaults, err := Encode(testServer{"localhost", 80})
+	assert.Nil(err)
+	user := mustAccessor(t, map[string]interface{}{"port": 8080})
+
+	merged, err := MergePatch(defaults, user)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"host": "localhost", "port": 8080}, merged.Unwrap())
+
+	root, err := ParseJSONPointer("")
+	assert.Nil(err)
+	server, err := GetAs[testServer](merged, root)
+	assert.Nil(err)
+	assert.Equal(testServer{"localhost", 8080}, server)
+}
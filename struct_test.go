@@ -0,0 +1,450 @@
+package accessor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testServer struct {
+	Host string `json:"host"`
+	Port int    `yaml:"port"`
+}
+
+type testMeta struct {
+	Version int `toml:"version"`
+}
+
+type testConfig struct {
+	testMeta
+	Name    string            `json:"name"`
+	Server  testServer        `json:"server"`
+	Backup  *testServer       `json:"backup,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Ports   []int             `json:"ports"`
+	Secret  string            `json:"-"`
+	Created time.Time         `json:"created"`
+	Plain   bool
+	private int
+}
+
+func TestStructAccessor_Get(t *testing.T) {
+	type Input struct {
+		Path string
+	}
+	type Expect struct {
+		Value interface{}
+		Err   error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	created := time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+	acc, err := NewAccessor(testConfig{
+		testMeta: testMeta{1},
+		Name:     "me",
+		Server:   testServer{"localhost", 80},
+		Ports:    []int{80, 443},
+		Secret:   "secret",
+		Created:  created,
+		Plain:    true,
+	})
+	assert.Nil(t, err)
+
+	table := []Test{
+		{
+			Title: "json tag",
+			Input: Input{
+				Path: "name",
+			},
+			Expect: Expect{
+				Value: "me",
+				Err:   nil,
+			},
+		},
+		{
+			Title: "nested struct",
+			Input: Input{
+				Path: "server/port",
+			},
+			Expect: Expect{
+				Value: 80,
+				Err:   nil,
+			},
+		},
+		{
+			Title: "embedded struct",
+			Input: Input{
+				Path: "version",
+			},
+			Expect: Expect{
+				Value: 1,
+				Err:   nil,
+			},
+		},
+		{
+			Title: "slice",
+			Input: Input{
+				Path: "ports/1",
+			},
+			Expect: Expect{
+				Value: 443,
+				Err:   nil,
+			},
+		},
+		{
+			Title: "field name",
+			Input: Input{
+				Path: "Plain",
+			},
+			Expect: Expect{
+				Value: true,
+				Err:   nil,
+			},
+		},
+		{
+			Title: "opaque struct",
+			Input: Input{
+				Path: "created",
+			},
+			Expect: Expect{
+				Value: created,
+				Err:   nil,
+			},
+		},
+		{
+			Title: "ignored",
+			Input: Input{
+				Path: "Secret",
+			},
+			Expect: Expect{
+				Value: nil,
+				Err:   NewNoSuchPathError("no such key", "Secret"),
+			},
+		},
+		{
+			Title: "unexported",
+			Input: Input{
+				Path: "private",
+			},
+			Expect: Expect{
+				Value: nil,
+				Err:   NewNoSuchPathError("no such key", "private"),
+			},
+		},
+		{
+			Title: "omitempty",
+			Input: Input{
+				Path: "labels",
+			},
+			Expect: Expect{
+				Value: nil,
+				Err:   NewNoSuchPathError("no such key", "labels"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+
+			found, err := acc.Get(path)
+			assert.Equal(testCase.Expect.Err, err)
+			if testCase.Expect.Err == nil {
+				assert.Equal(testCase.Expect.Value, found.Unwrap())
+			}
+		})
+	}
+}
+
+func TestStructAccessor_Set(t *testing.T) {
+	type Input struct {
+		Path  string
+		Value interface{}
+	}
+	type Expect struct {
+		Config testConfig
+		Err    error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "field",
+			Input: Input{
+				Path:  "name",
+				Value: "you",
+			},
+			Expect: Expect{
+				Config: testConfig{Name: "you", Labels: map[string]string{"a": "b"}, Ports: []int{80}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "nested struct",
+			Input: Input{
+				Path:  "server/host",
+				Value: "example.com",
+			},
+			Expect: Expect{
+				Config: testConfig{Server: testServer{Host: "example.com"}, Labels: map[string]string{"a": "b"}, Ports: []int{80}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "map",
+			Input: Input{
+				Path:  "labels/a",
+				Value: "c",
+			},
+			Expect: Expect{
+				Config: testConfig{Labels: map[string]string{"a": "c"}, Ports: []int{80}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "append",
+			Input: Input{
+				Path:  "ports/-",
+				Value: 443,
+			},
+			Expect: Expect{
+				Config: testConfig{Labels: map[string]string{"a": "b"}, Ports: []int{80, 443}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "type mismatch",
+			Input: Input{
+				Path:  "name",
+				Value: 1,
+			},
+			Expect: Expect{
+				Config: testConfig{Labels: map[string]string{"a": "b"}, Ports: []int{80}},
+				Err:    NewTypeMismatchError(1, reflect.TypeOf(""), pathFromKeys([]string{"name"})),
+			},
+		},
+		{
+			Title: "nested type mismatch",
+			Input: Input{
+				Path:  "ports/0",
+				Value: "x",
+			},
+			Expect: Expect{
+				Config: testConfig{Labels: map[string]string{"a": "b"}, Ports: []int{80}},
+				Err:    NewTypeMismatchError("x", reflect.TypeOf(0), pathFromKeys([]string{"ports", "0"})),
+			},
+		},
+		{
+			Title: "no such field",
+			Input: Input{
+				Path:  "foo",
+				Value: 1,
+			},
+			Expect: Expect{
+				Config: testConfig{Labels: map[string]string{"a": "b"}, Ports: []int{80}},
+				Err:    NewNoSuchPathError("no such key", "foo"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			config := testConfig{Labels: map[string]string{"a": "b"}, Ports: []int{80}}
+			acc, err := NewAccessor(&config)
+			assert.Nil(err)
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+
+			err = acc.Set(path, testCase.Input.Value)
+			assert.Equal(testCase.Expect.Err, err)
+			assert.Equal(testCase.Expect.Config, config)
+			assert.Equal(&config, acc.Unwrap())
+		})
+	}
+}
+
+func TestStructAccessor_SetElement(t *testing.T) {
+	type cluster struct {
+		Servers  []testServer          `json:"servers"`
+		Replicas []*testServer         `json:"replicas"`
+		Zones    map[string]testServer `json:"zones"`
+	}
+	type Input struct {
+		Path  string
+		Value interface{}
+	}
+	type Expect struct {
+		Cluster cluster
+		Err     error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "slice of structs",
+			Input: Input{
+				Path:  "servers/0/port",
+				Value: 99,
+			},
+			Expect: Expect{
+				Cluster: cluster{
+					Servers:  []testServer{{"a", 99}},
+					Replicas: []*testServer{{"b", 2}},
+					Zones:    map[string]testServer{"x": {"c", 3}},
+				},
+				Err: nil,
+			},
+		},
+		{
+			Title: "slice of pointers",
+			Input: Input{
+				Path:  "replicas/0/port",
+				Value: 99,
+			},
+			Expect: Expect{
+				Cluster: cluster{
+					Servers:  []testServer{{"a", 1}},
+					Replicas: []*testServer{{"b", 99}},
+					Zones:    map[string]testServer{"x": {"c", 3}},
+				},
+				Err: nil,
+			},
+		},
+		{
+			Title: "map of structs",
+			Input: Input{
+				Path:  "zones/x/host",
+				Value: "z",
+			},
+			Expect: Expect{
+				Cluster: cluster{
+					Servers:  []testServer{{"a", 1}},
+					Replicas: []*testServer{{"b", 2}},
+					Zones:    map[string]testServer{"x": {"z", 3}},
+				},
+				Err: nil,
+			},
+		},
+		{
+			Title: "field type mismatch",
+			Input: Input{
+				Path:  "servers/0/port",
+				Value: "x",
+			},
+			Expect: Expect{
+				Cluster: cluster{
+					Servers:  []testServer{{"a", 1}},
+					Replicas: []*testServer{{"b", 2}},
+					Zones:    map[string]testServer{"x": {"c", 3}},
+				},
+				Err: NewTypeMismatchError("x", reflect.TypeOf(0), pathFromKeys([]string{"servers", "0", "port"})),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			c := cluster{
+				Servers:  []testServer{{"a", 1}},
+				Replicas: []*testServer{{"b", 2}},
+				Zones:    map[string]testServer{"x": {"c", 3}},
+			}
+			acc, err := NewAccessor(&c)
+			assert.Nil(err)
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+
+			err = acc.Set(path, testCase.Input.Value)
+			assert.Equal(testCase.Expect.Err, err)
+			assert.Equal(testCase.Expect.Cluster, c)
+			assert.Equal(&c, acc.Unwrap())
+		})
+	}
+}
+
+func TestStructAccessor_Foreach(t *testing.T) {
+	assert := assert.New(t)
+
+	acc, err := NewAccessor(testServer{"localhost", 80})
+	assert.Nil(err)
+
+	var paths []string
+	err = acc.Foreach(func(path Path, value interface{}) error {
+		paths = append(paths, path.String())
+		return acc.Set(path, value)
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"host", "port"}, paths)
+	assert.Equal(testServer{"localhost", 80}, acc.Unwrap())
+}
+
+func TestStructAccessor_Clone(t *testing.T) {
+	assert := assert.New(t)
+
+	config := &testConfig{Labels: map[string]string{"a": "b"}}
+	acc, err := NewAccessor(config)
+	assert.Nil(err)
+
+	clone := Clone(acc)
+	assert.Nil(clone.Set(pathFromKeys([]string{"labels", "a"}), "c"))
+	assert.Equal(map[string]string{"a": "b"}, config.Labels)
+	assert.Equal(map[string]string{"a": "c"}, clone.Unwrap().(*testConfig).Labels)
+}
+
+func TestStructAccessor_QueryJSONPath(t *testing.T) {
+	assert := assert.New(t)
+
+	acc := MapAccessor(map[string]Accessor{
+		"s": mustAccessor(t, &testServer{"localhost", 80}),
+	})
+
+	matches, err := QueryJSONPath(acc, "$.s.*")
+	assert.Nil(err)
+	var values []interface{}
+	for _, m := range matches {
+		values = append(values, m.Accessor.Unwrap())
+	}
+	assert.Equal([]interface{}{"localhost", 80}, values)
+}
+
+func TestStructAccessor_CloneDeep(t *testing.T) {
+	assert := assert.New(t)
+
+	type meta struct {
+		Names map[int]string
+	}
+	type keyed struct {
+		meta
+		Servers []*testServer
+	}
+	obj := &keyed{meta{map[int]string{1: "a"}}, []*testServer{{"localhost", 80}}}
+	acc, err := NewAccessor(obj)
+	assert.Nil(err)
+
+	clone := Clone(acc).Unwrap().(*keyed)
+	clone.Names[1] = "b"
+	clone.Servers[0].Port = 8080
+	assert.Equal(&keyed{meta{map[int]string{1: "a"}}, []*testServer{{"localhost", 80}}}, obj)
+	assert.Equal(&keyed{meta{map[int]string{1: "b"}}, []*testServer{{"localhost", 8080}}}, clone)
+}
@@ -0,0 +1,409 @@
+package accessor
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+var (
+	mapType      = reflect.TypeOf(map[string]interface{}{})
+	keyedMapType = reflect.TypeOf(map[interface{}]interface{}{})
+	sliceType    = reflect.TypeOf([]interface{}{})
+)
+
+// TypedAccessor is the Accessor for a map or a slice of a specific type,
+// such as map[string]string or []int, created by NewAccessor with PreserveTypes.
+// Unwrap rebuilds the value of Type, and Set, SetCreate and Insert fail with
+// TypeMismatchError when the value cannot be converted into the type.
+type TypedAccessor struct {
+	Base Accessor
+	Type reflect.Type
+}
+
+// Get implements Accessor.
+func (a *TypedAccessor) Get(path Path) (Accessor, error) {
+	if path == thePhantomPath {
+		return a, nil
+	}
+	return a.Base.Get(path)
+}
+
+// Set implements Accessor.
+// Unlike SliceAccessor, a root slice can be appended since TypedAccessor is a pointer.
+func (a *TypedAccessor) Set(path Path, value interface{}) error {
+	_, err := a.set(path, value)
+	return err
+}
+
+func (a *TypedAccessor) set(path Path, value interface{}) (Accessor, error) {
+	if err := a.check(path, value); err != nil {
+		return nil, err
+	}
+	base, err := setTo(a.Base, path, value)
+	if err != nil {
+		return nil, err
+	}
+	a.Base = base
+	return a, nil
+}
+
+// SetCreate is same as Set but creates missing objects on the path.
+func (a *TypedAccessor) SetCreate(path Path, value interface{}) error {
+	_, err := a.setCreate(path, value)
+	return err
+}
+
+func (a *TypedAccessor) setCreate(path Path, value interface{}) (Accessor, error) {
+	if err := a.check(path, value); err != nil {
+		return nil, err
+	}
+	base, err := setCreateTo(a.Base, path, value)
+	if err != nil {
+		return nil, err
+	}
+	a.Base = base
+	return a, nil
+}
+
+// Insert inserts the value into the slice at the path.
+func (a *TypedAccessor) Insert(path Path, value interface{}) error {
+	_, err := a.insert(path, value)
+	return err
+}
+
+func (a *TypedAccessor) insert(path Path, value interface{}) (Accessor, error) {
+	if err := a.check(path, value); err != nil {
+		return nil, err
+	}
+	base, err := insertTo(a.Base, path, value)
+	if err != nil {
+		return nil, err
+	}
+	a.Base = base
+	return a, nil
+}
+
+// Delete implements Accessor.
+func (a *TypedAccessor) Delete(path Path) error {
+	_, err := a.delete(path)
+	return err
+}
+
+func (a *TypedAccessor) delete(path Path) (Accessor, error) {
+	base, err := deleteFrom(a.Base, path)
+	if err != nil {
+		return nil, err
+	}
+	a.Base = base
+	return a, nil
+}
+
+// Unwrap implements Accessor.
+// It returns the value of Type, or the value unwrapped by Base as is
+// if it cannot be converted into Type, which happens only when Base is modified directly.
+// Use UnwrapTyped to detect it.
+func (a *TypedAccessor) Unwrap() interface{} {
+	v, err := a.UnwrapTyped()
+	if err != nil {
+		return a.Base.Unwrap()
+	}
+	return v
+}
+
+// UnwrapTyped is same as Unwrap, but returns TypeMismatchError
+// instead of the value of Base when it cannot be converted into Type.
+func (a *TypedAccessor) UnwrapTyped() (interface{}, error) {
+	v := a.Base.Unwrap()
+	rv, ok := convertValue(v, a.Type)
+	if !ok {
+		return nil, NewTypeMismatchError(v, a.Type, thePhantomPath)
+	}
+	return rv.Interface(), nil
+}
+
+// Foreach implements Accessor.
+func (a *TypedAccessor) Foreach(f func(path Path, value interface{}) error) error {
+	return a.Base.Foreach(f)
+}
+
+func (a *TypedAccessor) base() Accessor {
+	return a.Base
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (a *TypedAccessor) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// MarshalYAML implements gopkg.in/yaml.v2.Marshaler.
+func (a *TypedAccessor) MarshalYAML() (interface{}, error) {
+	return marshalYAML(a)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the JSON encoding.
+func (a *TypedAccessor) MarshalText() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// check checks that the value can be stored at the path without breaking Type.
+func (a *TypedAccessor) check(path Path, value interface{}) error {
+	if path == thePhantomPath {
+		return nil
+	}
+
+	t := a.Type
+	for p, ok := path, true; ok; p, ok = p.SubPath() {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Interface:
+			return nil
+		case reflect.Map:
+			if _, ok := convertKey(p.Key(), t.Key()); !ok {
+				return NewTypeMismatchError(p.Key(), t.Key(), path)
+			}
+			t = t.Elem()
+		case reflect.Slice:
+			t = t.Elem()
+		case reflect.Struct:
+			if isOpaque(t) {
+				return NewTypeMismatchError(value, t, path)
+			}
+			f, ok := structFieldOf(t, p.Key())
+			if !ok {
+				// The missing field is reported by the StructAccessor.
+				return nil
+			}
+			t = f.Type
+		default:
+			return NewTypeMismatchError(value, t, path)
+		}
+	}
+
+	if _, ok := convertValue(value, t); !ok {
+		return NewTypeMismatchError(value, t, path)
+	}
+	return nil
+}
+
+// structFieldOf finds the field of the struct type by the key in the same way as StructAccessor.
+func structFieldOf(t reflect.Type, key string) (reflect.StructField, bool) {
+	for _, f := range structFields(t) {
+		if f.name == key {
+			return t.FieldByIndex(f.index), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// withType wraps the container with TypedAccessor when PreserveTypes is given
+// and the container cannot be unwrapped into the type t by itself.
+func withType(acc Accessor, t reflect.Type, o *options) Accessor {
+	if !o.preserveTypes {
+		return acc
+	}
+
+	var u reflect.Type
+	switch acc.(type) {
+	case KeyedMapAccessor:
+		u = keyedMapType
+	case MapAccessor:
+		u = mapType
+	case SliceAccessor:
+		u = sliceType
+	}
+	if u == t {
+		return acc
+	}
+	return &TypedAccessor{acc, t}
+}
+
+// convertValue converts v into the type t.
+// Maps and slices are converted recursively, and numbers are converted
+// only when no information is lost.
+func convertValue(v interface{}, t reflect.Type) (reflect.Value, bool) {
+	if v == nil {
+		switch t.Kind() {
+		case reflect.Interface, reflect.Map, reflect.Slice, reflect.Ptr:
+			return reflect.Zero(t), true
+		default:
+			return reflect.Value{}, false
+		}
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Type().AssignableTo(t) {
+		return rv, true
+	}
+
+	switch t.Kind() {
+	case reflect.Map:
+		if rv.Kind() != reflect.Map {
+			return reflect.Value{}, false
+		}
+		result := reflect.MakeMapWithSize(t, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k, ok := convertMapKey(iter.Key().Interface(), t.Key())
+			if !ok {
+				return reflect.Value{}, false
+			}
+			e, ok := convertValue(iter.Value().Interface(), t.Elem())
+			if !ok {
+				return reflect.Value{}, false
+			}
+			result.SetMapIndex(k, e)
+		}
+		return result, true
+	case reflect.Slice:
+		if rv.Kind() != reflect.Slice {
+			return reflect.Value{}, false
+		}
+		result := reflect.MakeSlice(t, rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			e, ok := convertValue(rv.Index(i).Interface(), t.Elem())
+			if !ok {
+				return reflect.Value{}, false
+			}
+			result.Index(i).Set(e)
+		}
+		return result, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if !isNumberKind(rv.Kind()) || !fitsSign(rv, t) {
+			return reflect.Value{}, false
+		}
+		result := rv.Convert(t)
+		if isFloatKind(rv.Kind()) && isFloatKind(t.Kind()) {
+			return result, fitsFloat(rv.Float(), t.Bits())
+		}
+		if result.Convert(rv.Type()).Interface() != v {
+			return reflect.Value{}, false
+		}
+		return result, true
+	case reflect.String, reflect.Bool:
+		if rv.Kind() != t.Kind() {
+			return reflect.Value{}, false
+		}
+		return rv.Convert(t), true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+// convertMapKey converts a map key into the type t.
+// A string key is parsed since a key added by Set is always a string.
+func convertMapKey(k interface{}, t reflect.Type) (reflect.Value, bool) {
+	if s, ok := k.(string); ok {
+		return convertKey(s, t)
+	}
+	return convertValue(k, t)
+}
+
+// convertKey parses the key of a Path into the type t.
+func convertKey(key string, t reflect.Type) (reflect.Value, bool) {
+	result := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		result.SetString(key)
+	case reflect.Interface:
+		if !reflect.TypeOf(key).AssignableTo(t) {
+			return reflect.Value{}, false
+		}
+		result.Set(reflect.ValueOf(key))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(key)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		result.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(key, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		result.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(key, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		result.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(key, t.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		result.SetFloat(f)
+	default:
+		return reflect.Value{}, false
+	}
+	return result, true
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// fitsSign reports whether the sign of the number is kept by the conversion into t,
+// since the round trip between the integers of the same size cannot detect it.
+func fitsSign(rv reflect.Value, t reflect.Type) bool {
+	switch {
+	case isUintKind(t.Kind()):
+		switch {
+		case isIntKind(rv.Kind()):
+			return rv.Int() >= 0
+		case isFloatKind(rv.Kind()):
+			return rv.Float() >= 0
+		}
+	case isIntKind(t.Kind()):
+		if isUintKind(rv.Kind()) {
+			return rv.Uint() <= math.MaxInt64
+		}
+	}
+	return true
+}
+
+// fitsFloat reports whether the float is kept by the conversion into the float of the bits.
+// The shortest decimal representations are compared instead of the bits,
+// since a decimal such as 0.1 is not exact in any precision.
+func fitsFloat(f float64, bits int) bool {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return true
+	}
+	g, err := strconv.ParseFloat(strconv.FormatFloat(f, 'g', -1, bits), 64)
+	return err == nil && g == f
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUintKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
@@ -0,0 +1,344 @@
+package accessor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccessor_PreserveTypes(t *testing.T) {
+	type Labels map[string]string
+	type Input struct {
+		Value   interface{}
+		Options []Option
+	}
+	type Expect struct {
+		Unwrapped interface{}
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "without option",
+			Input: Input{
+				Value:   map[string]string{"a": "b"},
+				Options: nil,
+			},
+			Expect: Expect{
+				Unwrapped: map[string]interface{}{"a": "b"},
+			},
+		},
+		{
+			Title: "map",
+			Input: Input{
+				Value:   map[string]string{"a": "b"},
+				Options: []Option{PreserveTypes()},
+			},
+			Expect: Expect{
+				Unwrapped: map[string]string{"a": "b"},
+			},
+		},
+		{
+			Title: "slice",
+			Input: Input{
+				Value:   []int{1, 2},
+				Options: []Option{PreserveTypes()},
+			},
+			Expect: Expect{
+				Unwrapped: []int{1, 2},
+			},
+		},
+		{
+			Title: "nested",
+			Input: Input{
+				Value: map[string]interface{}{
+					"a": map[string][]int{"b": {1}},
+					"c": []interface{}{[]string{"d"}},
+				},
+				Options: []Option{PreserveTypes()},
+			},
+			Expect: Expect{
+				Unwrapped: map[string]interface{}{
+					"a": map[string][]int{"b": {1}},
+					"c": []interface{}{[]string{"d"}},
+				},
+			},
+		},
+		{
+			Title: "named type",
+			Input: Input{
+				Value:   Labels{"a": "b"},
+				Options: []Option{PreserveTypes()},
+			},
+			Expect: Expect{
+				Unwrapped: Labels{"a": "b"},
+			},
+		},
+		{
+			Title: "non-string keys",
+			Input: Input{
+				Value:   map[int]string{1: "a"},
+				Options: []Option{PreserveTypes(), StringifyKeys()},
+			},
+			Expect: Expect{
+				Unwrapped: map[int]string{1: "a"},
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			acc, err := NewAccessor(testCase.Input.Value, testCase.Input.Options...)
+			assert.Nil(err)
+			assert.Equal(testCase.Expect.Unwrapped, acc.Unwrap())
+		})
+	}
+}
+
+func TestTypedAccessor_Set(t *testing.T) {
+	type Input struct {
+		Value interface{}
+		Path  string
+		Set   interface{}
+	}
+	type Expect struct {
+		Unwrapped interface{}
+		Err       error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "success",
+			Input: Input{
+				Value: map[string]int{"a": 1},
+				Path:  "a",
+				Set:   2,
+			},
+			Expect: Expect{
+				Unwrapped: map[string]int{"a": 2},
+				Err:       nil,
+			},
+		},
+		{
+			Title: "convert number",
+			Input: Input{
+				Value: []int{1},
+				Path:  "0",
+				Set:   float64(2),
+			},
+			Expect: Expect{
+				Unwrapped: []int{2},
+				Err:       nil,
+			},
+		},
+		{
+			Title: "append to root",
+			Input: Input{
+				Value: []int{1},
+				Path:  "-",
+				Set:   2,
+			},
+			Expect: Expect{
+				Unwrapped: []int{1, 2},
+				Err:       nil,
+			},
+		},
+		{
+			Title: "nested",
+			Input: Input{
+				Value: map[string][]string{"a": {"b"}},
+				Path:  "a/0",
+				Set:   "c",
+			},
+			Expect: Expect{
+				Unwrapped: map[string][]string{"a": {"c"}},
+				Err:       nil,
+			},
+		},
+		{
+			Title: "type mismatch",
+			Input: Input{
+				Value: map[string][]int{"a": {1}},
+				Path:  "a/0",
+				Set:   "x",
+			},
+			Expect: Expect{
+				Unwrapped: map[string][]int{"a": {1}},
+				Err:       NewTypeMismatchError("x", reflect.TypeOf(0), pathFromKeys([]string{"a", "0"})),
+			},
+		},
+		{
+			Title: "lossy number",
+			Input: Input{
+				Value: []int{1},
+				Path:  "0",
+				Set:   1.5,
+			},
+			Expect: Expect{
+				Unwrapped: []int{1},
+				Err:       NewTypeMismatchError(1.5, reflect.TypeOf(0), pathFromKeys([]string{"0"})),
+			},
+		},
+		{
+			Title: "negative into unsigned",
+			Input: Input{
+				Value: map[string]uint{"a": 1},
+				Path:  "a",
+				Set:   -1,
+			},
+			Expect: Expect{
+				Unwrapped: map[string]uint{"a": 1},
+				Err:       NewTypeMismatchError(-1, reflect.TypeOf(uint(0)), pathFromKeys([]string{"a"})),
+			},
+		},
+		{
+			Title: "too large for signed",
+			Input: Input{
+				Value: []int64{1},
+				Path:  "0",
+				Set:   uint64(math.MaxUint64),
+			},
+			Expect: Expect{
+				Unwrapped: []int64{1},
+				Err:       NewTypeMismatchError(uint64(math.MaxUint64), reflect.TypeOf(int64(0)), pathFromKeys([]string{"0"})),
+			},
+		},
+		{
+			Title: "negative float into unsigned",
+			Input: Input{
+				Value: []uint8{1},
+				Path:  "0",
+				Set:   float64(-1),
+			},
+			Expect: Expect{
+				Unwrapped: []uint8{1},
+				Err:       NewTypeMismatchError(float64(-1), reflect.TypeOf(uint8(0)), pathFromKeys([]string{"0"})),
+			},
+		},
+		{
+			Title: "decimal into float32",
+			Input: Input{
+				Value: []float32{1},
+				Path:  "0",
+				Set:   0.1,
+			},
+			Expect: Expect{
+				Unwrapped: []float32{0.1},
+				Err:       nil,
+			},
+		},
+		{
+			Title: "too large for float32",
+			Input: Input{
+				Value: []float32{1},
+				Path:  "0",
+				Set:   1e300,
+			},
+			Expect: Expect{
+				Unwrapped: []float32{1},
+				Err:       NewTypeMismatchError(1e300, reflect.TypeOf(float32(0)), pathFromKeys([]string{"0"})),
+			},
+		},
+		{
+			Title: "too precise for float32",
+			Input: Input{
+				Value: []float32{1},
+				Path:  "0",
+				Set:   0.1000000001,
+			},
+			Expect: Expect{
+				Unwrapped: []float32{1},
+				Err:       NewTypeMismatchError(0.1000000001, reflect.TypeOf(float32(0)), pathFromKeys([]string{"0"})),
+			},
+		},
+		{
+			Title: "invalid key",
+			Input: Input{
+				Value: map[int]string{1: "a"},
+				Path:  "b",
+				Set:   "c",
+			},
+			Expect: Expect{
+				Unwrapped: map[int]string{1: "a"},
+				Err:       NewTypeMismatchError("b", reflect.TypeOf(0), pathFromKeys([]string{"b"})),
+			},
+		},
+		{
+			Title: "mismatch in untyped parent",
+			Input: Input{
+				Value: map[string]interface{}{"a": []bool{true}},
+				Path:  "a/0",
+				Set:   1,
+			},
+			Expect: Expect{
+				Unwrapped: map[string]interface{}{"a": []bool{true}},
+				Err:       NewTypeMismatchError(1, reflect.TypeOf(true), pathFromKeys([]string{"a", "0"})),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			acc, err := NewAccessor(testCase.Input.Value, PreserveTypes(), StringifyKeys())
+			assert.Nil(err)
+			path, err := ParsePath(testCase.Input.Path)
+			assert.Nil(err)
+
+			err = acc.Set(path, testCase.Input.Set)
+			assert.Equal(testCase.Expect.Err, err)
+			assert.Equal(testCase.Expect.Unwrapped, acc.Unwrap())
+		})
+	}
+}
+
+func TestTypedAccessor_UnwrapTyped(t *testing.T) {
+	assert := assert.New(t)
+
+	acc, err := NewAccessor(map[string]int{"a": 1}, PreserveTypes())
+	assert.Nil(err)
+	typed := acc.(*TypedAccessor)
+
+	v, err := typed.UnwrapTyped()
+	assert.Nil(err)
+	assert.Equal(map[string]int{"a": 1}, v)
+
+	assert.Nil(typed.Base.Set(newPath("a"), "x"))
+	v, err = typed.UnwrapTyped()
+	assert.Nil(v)
+	assert.Equal(NewTypeMismatchError(map[string]interface{}{"a": "x"}, reflect.TypeOf(map[string]int{}), thePhantomPath), err)
+	assert.Equal(map[string]interface{}{"a": "x"}, typed.Unwrap())
+}
+
+func TestTypedAccessor_Insert(t *testing.T) {
+	assert := assert.New(t)
+
+	acc, err := NewAccessor([]string{"a", "c"}, PreserveTypes())
+	assert.Nil(err)
+	ta := acc.(*TypedAccessor)
+
+	assert.Nil(ta.Insert(pathFromKeys([]string{"1"}), "b"))
+	assert.Equal([]string{"a", "b", "c"}, ta.Unwrap())
+
+	assert.Equal(
+		NewTypeMismatchError(nil, reflect.TypeOf(""), pathFromKeys([]string{"0"})),
+		ta.Insert(pathFromKeys([]string{"0"}), nil),
+	)
+
+	assert.Nil(ta.Delete(pathFromKeys([]string{"0"})))
+	assert.Equal([]string{"b", "c"}, ta.Unwrap())
+}
@@ -0,0 +1,123 @@
+package accessor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// FromJSON decodes a JSON document from the reader and creates a new Accessor.
+// Like json.Unmarshal, the reader must have only one value.
+func FromJSON(r io.Reader, opts ...Option) (Accessor, error) {
+	o := newOptions(opts)
+	dec := json.NewDecoder(r)
+	var v interface{}
+	var err error
+	if o.preserveOrder {
+		v, err = decodeOrderedJSON(dec)
+	} else {
+		err = dec.Decode(&v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			err = fmt.Errorf("invalid data after top-level value at offset %d", dec.InputOffset())
+		}
+		return nil, err
+	}
+	return newAccessor(v, o)
+}
+
+// FromYAML decodes a YAML document from the reader and creates a new Accessor.
+// Use StringifyKeys if the document has non-string keys.
+func FromYAML(r io.Reader, opts ...Option) (Accessor, error) {
+	o := newOptions(opts)
+	if o.preserveOrder {
+		v, err := decodeOrderedYAML(r)
+		if err != nil {
+			return nil, err
+		}
+		return newAccessor(v, o)
+	}
+
+	bs, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := yaml.Unmarshal(bs, &v); err != nil {
+		return nil, err
+	}
+	return newAccessor(v, o)
+}
+
+// FromTOML decodes a TOML document from the reader and creates a new Accessor.
+func FromTOML(r io.Reader, opts ...Option) (Accessor, error) {
+	var v map[string]interface{}
+	if _, err := toml.DecodeReader(r, &v); err != nil {
+		return nil, err
+	}
+	return newAccessor(v, newOptions(opts))
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler,
+// so that MapAccessor can be a field of a struct decoded from JSON.
+func (a *MapAccessor) UnmarshalJSON(data []byte) error {
+	var v map[string]interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return a.unmarshal(v)
+}
+
+// UnmarshalYAML implements gopkg.in/yaml.v2.Unmarshaler.
+func (a *MapAccessor) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v map[string]interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	return a.unmarshal(v)
+}
+
+func (a *MapAccessor) unmarshal(v map[string]interface{}) error {
+	acc, err := NewAccessor(v)
+	if err != nil {
+		return err
+	}
+	*a = acc.(MapAccessor)
+	return nil
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler,
+// so that SliceAccessor can be a field of a struct decoded from JSON.
+func (a *SliceAccessor) UnmarshalJSON(data []byte) error {
+	var v []interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return a.unmarshal(v)
+}
+
+// UnmarshalYAML implements gopkg.in/yaml.v2.Unmarshaler.
+func (a *SliceAccessor) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v []interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	return a.unmarshal(v)
+}
+
+func (a *SliceAccessor) unmarshal(v []interface{}) error {
+	acc, err := NewAccessor(v)
+	if err != nil {
+		return err
+	}
+	*a = acc.(SliceAccessor)
+	return nil
+}
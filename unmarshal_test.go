@@ -0,0 +1,191 @@
+package accessor
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestFrom(t *testing.T) {
+	type Input struct {
+		Decode func(r io.Reader, opts ...Option) (Accessor, error)
+		Text   string
+	}
+	type Expect struct {
+		Unwrapped interface{}
+		IsErr     bool
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "json",
+			Input: Input{
+				Decode: FromJSON,
+				Text:   `{"name": "me", "friends": [{"name": "hello"}]}`,
+			},
+			Expect: Expect{
+				Unwrapped: map[string]interface{}{
+					"name":    "me",
+					"friends": []interface{}{map[string]interface{}{"name": "hello"}},
+				},
+				IsErr: false,
+			},
+		},
+		{
+			Title: "yaml",
+			Input: Input{
+				Decode: FromYAML,
+				Text:   "name: me\nfriends:\n  - name: hello\n",
+			},
+			Expect: Expect{
+				Unwrapped: map[string]interface{}{
+					"name":    "me",
+					"friends": []interface{}{map[string]interface{}{"name": "hello"}},
+				},
+				IsErr: false,
+			},
+		},
+		{
+			Title: "toml",
+			Input: Input{
+				Decode: FromTOML,
+				Text:   "name = \"me\"\n[[friends]]\nname = \"hello\"\n",
+			},
+			Expect: Expect{
+				Unwrapped: map[string]interface{}{
+					"name":    "me",
+					"friends": []interface{}{map[string]interface{}{"name": "hello"}},
+				},
+				IsErr: false,
+			},
+		},
+		{
+			Title: "invalid json",
+			Input: Input{
+				Decode: FromJSON,
+				Text:   `{"name": `,
+			},
+			Expect: Expect{
+				Unwrapped: nil,
+				IsErr:     true,
+			},
+		},
+		{
+			Title: "trailing space",
+			Input: Input{
+				Decode: FromJSON,
+				Text:   "{\"a\": 1}\n",
+			},
+			Expect: Expect{
+				Unwrapped: map[string]interface{}{"a": float64(1)},
+				IsErr:     false,
+			},
+		},
+		{
+			Title: "trailing garbage",
+			Input: Input{
+				Decode: FromJSON,
+				Text:   `{"a": 1} garbage`,
+			},
+			Expect: Expect{
+				Unwrapped: nil,
+				IsErr:     true,
+			},
+		},
+		{
+			Title: "trailing value",
+			Input: Input{
+				Decode: FromJSON,
+				Text:   `{"a": 1} {"b": 2}`,
+			},
+			Expect: Expect{
+				Unwrapped: nil,
+				IsErr:     true,
+			},
+		},
+		{
+			Title: "trailing garbage with order",
+			Input: Input{
+				Decode: func(r io.Reader, opts ...Option) (Accessor, error) {
+					return FromJSON(r, PreserveOrder())
+				},
+				Text: `{"a": 1} garbage`,
+			},
+			Expect: Expect{
+				Unwrapped: nil,
+				IsErr:     true,
+			},
+		},
+		{
+			Title: "non-string keys",
+			Input: Input{
+				Decode: FromYAML,
+				Text:   "1: a\n",
+			},
+			Expect: Expect{
+				Unwrapped: nil,
+				IsErr:     true,
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			acc, err := testCase.Input.Decode(strings.NewReader(testCase.Input.Text))
+			assert.Equal(testCase.Expect.IsErr, err != nil)
+			if err == nil {
+				assert.Equal(testCase.Expect.Unwrapped, acc.Unwrap())
+			}
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	type Config struct {
+		Name    string        `json:"name" yaml:"name"`
+		Extra   MapAccessor   `json:"extra" yaml:"extra"`
+		Friends SliceAccessor `json:"friends" yaml:"friends"`
+	}
+
+	expect := Config{
+		Name: "me",
+		Extra: MapAccessor{
+			"a": SliceAccessor{&ValueAccessor{true}},
+		},
+		Friends: SliceAccessor{
+			MapAccessor{"name": &ValueAccessor{"hello"}},
+		},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		assert := assert.New(t)
+
+		var config Config
+		err := json.Unmarshal([]byte(`{"name": "me", "extra": {"a": [true]}, "friends": [{"name": "hello"}]}`), &config)
+		assert.Nil(err)
+		assert.Equal(expect, config)
+
+		err = json.Unmarshal([]byte(`{"extra": []}`), &config)
+		assert.NotNil(err)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		assert := assert.New(t)
+
+		var config Config
+		err := yaml.Unmarshal([]byte("name: me\nextra:\n  a: [true]\nfriends:\n  - name: hello\n"), &config)
+		assert.Nil(err)
+		assert.Equal(expect, config)
+	})
+}
@@ -24,6 +24,7 @@ func Get(i interface{}, path string) (interface{}, error) {
 }
 
 // Update updates a value in the object by the path and returns updated object.
+// Missing objects in the path are created as with SetCreate.
 func Update(i interface{}, path string, value interface{}) (interface{}, error) {
 	if path == "/" {
 		return nil, NewInvalidPathError(path)
@@ -38,7 +39,29 @@ func Update(i interface{}, path string, value interface{}) (interface{}, error)
 		return nil, err
 	}
 
-	err = acc.Set(p, value)
+	acc, err = setCreateTo(acc, p, value)
+	if err != nil {
+		return nil, err
+	}
+	return acc.Unwrap(), nil
+}
+
+// Delete deletes a value in the object by the path and returns updated object.
+func Delete(i interface{}, path string) (interface{}, error) {
+	if path == "/" {
+		return nil, NewInvalidPathError(path)
+	}
+	p, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := NewAccessor(i)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err = deleteFrom(acc, p)
 	if err != nil {
 		return nil, err
 	}
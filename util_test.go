@@ -0,0 +1,197 @@
+package accessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdate(t *testing.T) {
+	type Input struct {
+		Object interface{}
+		Path   string
+		Value  interface{}
+	}
+	type Expect struct {
+		Object interface{}
+		Err    error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "existing key",
+			Input: Input{
+				Object: map[string]interface{}{"a": 1},
+				Path:   "/a",
+				Value:  2,
+			},
+			Expect: Expect{
+				Object: map[string]interface{}{"a": 2},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "create missing maps",
+			Input: Input{
+				Object: map[string]interface{}{},
+				Path:   "/a/b/c",
+				Value:  1,
+			},
+			Expect: Expect{
+				Object: map[string]interface{}{
+					"a": map[string]interface{}{
+						"b": map[string]interface{}{"c": 1},
+					},
+				},
+				Err: nil,
+			},
+		},
+		{
+			Title: "create missing slice",
+			Input: Input{
+				Object: map[string]interface{}{},
+				Path:   "/a/-",
+				Value:  1,
+			},
+			Expect: Expect{
+				Object: map[string]interface{}{"a": []interface{}{1}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "append to root slice",
+			Input: Input{
+				Object: []interface{}{1},
+				Path:   "/-",
+				Value:  2,
+			},
+			Expect: Expect{
+				Object: []interface{}{1, 2},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "root",
+			Input: Input{
+				Object: map[string]interface{}{},
+				Path:   "/",
+				Value:  1,
+			},
+			Expect: Expect{
+				Object: nil,
+				Err:    NewInvalidPathError("/"),
+			},
+		},
+		{
+			Title: "key of value",
+			Input: Input{
+				Object: map[string]interface{}{"a": 1},
+				Path:   "/a/b",
+				Value:  2,
+			},
+			Expect: Expect{
+				Object: nil,
+				Err:    NewNoSuchPathError("int(1) has no key", "b", "a"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			obj, err := Update(testCase.Input.Object, testCase.Input.Path, testCase.Input.Value)
+			assert.Equal(testCase.Expect.Err, err)
+			assert.Equal(testCase.Expect.Object, obj)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type Input struct {
+		Object interface{}
+		Path   string
+	}
+	type Expect struct {
+		Object interface{}
+		Err    error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "map key",
+			Input: Input{
+				Object: map[string]interface{}{"a": 1, "b": 2},
+				Path:   "/a",
+			},
+			Expect: Expect{
+				Object: map[string]interface{}{"b": 2},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "nested slice element",
+			Input: Input{
+				Object: map[string]interface{}{"a": []interface{}{1, 2, 3}},
+				Path:   "/a/1",
+			},
+			Expect: Expect{
+				Object: map[string]interface{}{"a": []interface{}{1, 3}},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "root slice element",
+			Input: Input{
+				Object: []interface{}{1, 2, 3},
+				Path:   "/0",
+			},
+			Expect: Expect{
+				Object: []interface{}{2, 3},
+				Err:    nil,
+			},
+		},
+		{
+			Title: "missing path",
+			Input: Input{
+				Object: map[string]interface{}{"a": map[string]interface{}{}},
+				Path:   "/a/b",
+			},
+			Expect: Expect{
+				Object: nil,
+				Err:    NewNoSuchPathError("no such key", "b", "a"),
+			},
+		},
+		{
+			Title: "root",
+			Input: Input{
+				Object: map[string]interface{}{},
+				Path:   "/",
+			},
+			Expect: Expect{
+				Object: nil,
+				Err:    NewInvalidPathError("/"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			obj, err := Delete(testCase.Input.Object, testCase.Input.Path)
+			assert.Equal(testCase.Expect.Err, err)
+			assert.Equal(testCase.Expect.Object, obj)
+		})
+	}
+}
@@ -28,6 +28,11 @@ func (a *ValueAccessor) Set(path Path, value interface{}) error {
 	return NewNoSuchPathError(fmt.Sprintf("%[1]T(%[1]v) has no key", a.Value), path.Key())
 }
 
+// Delete implements Accessor.
+func (a *ValueAccessor) Delete(path Path) error {
+	return NewNoSuchPathError(fmt.Sprintf("%[1]T(%[1]v) has no key", a.Value), path.Key())
+}
+
 // Unwrap implements Accessor.
 func (a *ValueAccessor) Unwrap() interface{} {
 	return a.Value
@@ -117,6 +117,48 @@ func TestValueAccessor_Set(t *testing.T) {
 	}
 }
 
+func TestValueAccessor_Delete(t *testing.T) {
+	type Input struct {
+		Accessor Accessor
+		Path     Path
+	}
+	type Expect struct {
+		Accessor Accessor
+		Err      error
+	}
+	type Test struct {
+		Title  string
+		Input  Input
+		Expect Expect
+	}
+
+	table := []Test{
+		{
+			Title: "error",
+			Input: Input{
+				Accessor: &ValueAccessor{1},
+				Path:     newPath("a"),
+			},
+			Expect: Expect{
+				Accessor: &ValueAccessor{1},
+				Err:      NewNoSuchPathError("int(1) has no key", "a"),
+			},
+		},
+	}
+
+	for _, testCase := range table {
+		t.Run(testCase.Title, func(t *testing.T) {
+			assert := assert.New(t)
+
+			acc := testCase.Input.Accessor
+			err := acc.Delete(testCase.Input.Path)
+
+			assert.Equal(testCase.Expect.Accessor, acc)
+			assert.Equal(testCase.Expect.Err, err)
+		})
+	}
+}
+
 func TestValueAccessor_Unwrap(t *testing.T) {
 	type Input struct {
 		Value interface{}